@@ -8,14 +8,84 @@ terraform {
   }
 }
 
-provider "starchitect" {}
+# Every attribute falls back to its STARCHITECT_* environment variable,
+# e.g. STARCHITECT_PAC_VERSION or STARCHITECT_THRESHOLD
+provider "starchitect" {
+  pac_version = var.pac_version
+  # pac_repository = "https://github.com/nonfx/starchitect-cloudguard"
+  # log_path = "../logs"
+  # engine_path = "/usr/local/bin/regula"
+  # cache_dir = "../.starchitect"
+  # cache_ttl = "1h"
+  # cache_retention = "168h"
+  # offline = true
+  # rule_pack_path = "../rule-packs"
+  # threshold = 50
+}
 
 resource "starchitect_iac_pac" "demo_example" {
     iac_path = var.iac_path
+    # plan_json_path = "plan.json" # instead of iac_path
     # pac_path = var.pac_path
     # pac_version = var.pac_version
+    # pac_repository = "git@git.example.com:security/cloudguard.git"
+    # pac_ref = "v1.2.0"
+    # pac_subdirectory = "terraform/aws"
+    # taxonomy_file = "taxonomy.yaml"
+    # pac_auth {
+    #   ssh_private_key_file = "~/.ssh/id_ed25519"
+    # }
+    # pac_verification {
+    #   commit        = "4f1c2d9"
+    #   checksum_file = "checksums.sha256"
+    #   public_key    = file("../keys/policies.pub")
+    # }
     threshold = var.threshold
+    # min_coverage = 90
+    # include_frameworks = ["CIS"]
+    # min_severity = "High"
+    # include_rules = ["S3.*"]
+    # exclude_rules = ["2.1.2"]
+    # families = ["CIS-AWS_v1.4.0"]
     log_path = var.log_path
+    # report_formats = ["raw", "summary", "sarif", "junit", "html"]
+    # scoring_mode = "weighted"
+    # severity_weights = {
+    #   Critical = 20
+    #   High = 10
+    # }
+
+    fail_on {
+      critical = 0
+      high     = 0
+      # medium   = 5
+      # rule_ids = ["2.1.2"]
+    }
+
+    # waiver {
+    #   rule_id     = "2.1.2"
+    #   resource_id = "module.ec2.*"
+    #   reason      = "AMI is rebuilt with encryption in Q3"
+    #   owner       = "platform-team"
+    #   expires     = "2025-09-30"
+    # }
+}
+
+data "starchitect_scan" "demo_check" {
+    iac_path = var.iac_path
+    threshold = var.threshold
+    log_path = var.log_path
+
+    fail_on {
+      critical = 0
+    }
+}
+
+check "security_scan" {
+  assert {
+    condition     = data.starchitect_scan.demo_check.passed
+    error_message = join("\n", data.starchitect_scan.demo_check.violations)
+  }
 }
 
 variable "iac_path" {
@@ -33,8 +103,8 @@ variable "pac_version" {
 
 variable "threshold" {
   description = "Minimum required security score (0-100)"
-  type = string
-  default = "50"
+  type = number
+  default = 50
 }
 
 variable "log_path" {
@@ -50,3 +120,22 @@ output "scan_result" {
 output "score" {
     value = starchitect_iac_pac.demo_example.score
 }
+
+output "score_percent" {
+    value = starchitect_iac_pac.demo_example.score_percent
+}
+
+output "failed_findings" {
+    value = [
+        for finding in starchitect_iac_pac.demo_example.findings :
+        "${finding.rule_id} ${finding.resource_id}: ${finding.message}"
+        if finding.rule_result == "FAIL"
+    ]
+}
+
+output "compliance_scores" {
+    value = {
+        for framework, compliance in starchitect_iac_pac.demo_example.compliance :
+        framework => compliance.score
+    }
+}
@@ -0,0 +1,213 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Control statuses of the compliance roll-up.
+const (
+	// ControlPass is a control of which every evaluated result passed.
+	ControlPass = "PASS"
+	// ControlFail is a control with at least one failing result.
+	ControlFail = "FAIL"
+	// ControlNotEvaluated is a control without PASS or FAIL results, e.g.
+	// when every failure was waived.
+	ControlNotEvaluated = "NOT_EVALUATED"
+)
+
+// complianceOtherFramework groups controls that do not name a framework.
+const complianceOtherFramework = "Other"
+
+// ControlCompliance is the status of a control of a compliance framework.
+type ControlCompliance struct {
+	ID     string
+	Status string
+	Passed int64
+	Failed int64
+	Waived int64
+	// RuleIDs are the rules reporting the control.
+	RuleIDs []string
+}
+
+// FrameworkCompliance rolls up the controls of a compliance framework, e.g. CIS-AWS_v1.4.0.
+type FrameworkCompliance struct {
+	Name     string
+	Controls []ControlCompliance
+	// Passed, Failed and NotEvaluated count the controls by status.
+	Passed       int64
+	Failed       int64
+	NotEvaluated int64
+}
+
+// Evaluated reports whether any control passed or failed.
+func (f FrameworkCompliance) Evaluated() bool {
+	return f.Passed+f.Failed > 0
+}
+
+// Percent returns the share of passed controls among the evaluated ones, 0 when not evaluated.
+func (f FrameworkCompliance) Percent() float64 {
+	if !f.Evaluated() {
+		return 0
+	}
+	return float64(f.Passed) / float64(f.Passed+f.Failed) * 100
+}
+
+// splitControl splits a control such as CIS-AWS_v1.4.0_2.1.2 into its
+// framework and control ID. The families of the rule name the frameworks,
+// other controls are split at their last underscore.
+func splitControl(control string, families []string) (string, string) {
+	for _, family := range families {
+		if id, ok := strings.CutPrefix(control, family+"_"); ok && id != "" {
+			return family, id
+		}
+	}
+	if i := strings.LastIndex(control, "_"); i > 0 && i < len(control)-1 {
+		return control[:i], control[i+1:]
+	}
+	return complianceOtherFramework, control
+}
+
+// calculateCompliance aggregates the controls of the rule results per
+// framework. A control fails when any of its results failed and passes when
+// the others passed, waived results neither pass nor fail it.
+func calculateCompliance(ruleResults []RegulaRuleResult) []FrameworkCompliance {
+	controls := map[string]map[string]*ControlCompliance{}
+	for _, rule := range ruleResults {
+		for _, control := range rule.Controls {
+			framework, id := splitControl(control, rule.Families)
+			if controls[framework] == nil {
+				controls[framework] = map[string]*ControlCompliance{}
+			}
+			status, ok := controls[framework][id]
+			if !ok {
+				status = &ControlCompliance{ID: id, RuleIDs: []string{}}
+				controls[framework][id] = status
+			}
+			switch rule.RuleResult {
+			case "PASS":
+				status.Passed++
+			case "FAIL":
+				status.Failed++
+			case "WAIVED":
+				status.Waived++
+			}
+			if !slices.Contains(status.RuleIDs, rule.RuleID) {
+				status.RuleIDs = append(status.RuleIDs, rule.RuleID)
+			}
+		}
+	}
+
+	frameworks := []FrameworkCompliance{}
+	for _, name := range utils.SortedKeys(controls) {
+		framework := FrameworkCompliance{Name: name, Controls: []ControlCompliance{}}
+		for _, id := range utils.SortedKeys(controls[name]) {
+			control := *controls[name][id]
+			sort.Strings(control.RuleIDs)
+			switch {
+			case control.Failed > 0:
+				control.Status = ControlFail
+				framework.Failed++
+			case control.Passed > 0:
+				control.Status = ControlPass
+				framework.Passed++
+			default:
+				control.Status = ControlNotEvaluated
+				framework.NotEvaluated++
+			}
+			framework.Controls = append(framework.Controls, control)
+		}
+		frameworks = append(frameworks, framework)
+	}
+	return frameworks
+}
+
+// ComplianceFrameworkModel describes the entries of the compliance attribute.
+type ComplianceFrameworkModel struct {
+	Score                types.Float64                     `tfsdk:"score"`
+	PassedControls       int64                             `tfsdk:"passed_controls"`
+	FailedControls       int64                             `tfsdk:"failed_controls"`
+	NotEvaluatedControls int64                             `tfsdk:"not_evaluated_controls"`
+	Controls             map[string]ComplianceControlModel `tfsdk:"controls"`
+}
+
+// ComplianceControlModel describes the controls of a compliance framework.
+type ComplianceControlModel struct {
+	Status  string   `tfsdk:"status"`
+	Passed  int64    `tfsdk:"passed"`
+	Failed  int64    `tfsdk:"failed"`
+	Waived  int64    `tfsdk:"waived"`
+	RuleIDs []string `tfsdk:"rule_ids"`
+}
+
+var complianceControlAttrTypes = map[string]attr.Type{
+	"status":   types.StringType,
+	"passed":   types.Int64Type,
+	"failed":   types.Int64Type,
+	"waived":   types.Int64Type,
+	"rule_ids": types.ListType{ElemType: types.StringType},
+}
+
+var complianceFrameworkAttrTypes = map[string]attr.Type{
+	"score":                  types.Float64Type,
+	"passed_controls":        types.Int64Type,
+	"failed_controls":        types.Int64Type,
+	"not_evaluated_controls": types.Int64Type,
+	"controls":               types.MapType{ElemType: types.ObjectType{AttrTypes: complianceControlAttrTypes}},
+}
+
+// complianceValue converts the frameworks into the compliance map value.
+func complianceValue(ctx context.Context, frameworks []FrameworkCompliance) (types.Map, diag.Diagnostics) {
+	models := map[string]ComplianceFrameworkModel{}
+	for _, framework := range frameworks {
+		model := ComplianceFrameworkModel{
+			Score:                types.Float64Null(),
+			PassedControls:       framework.Passed,
+			FailedControls:       framework.Failed,
+			NotEvaluatedControls: framework.NotEvaluated,
+			Controls:             map[string]ComplianceControlModel{},
+		}
+		if framework.Evaluated() {
+			model.Score = types.Float64Value(framework.Percent())
+		}
+		for _, control := range framework.Controls {
+			model.Controls[control.ID] = ComplianceControlModel{
+				Status:  control.Status,
+				Passed:  control.Passed,
+				Failed:  control.Failed,
+				Waived:  control.Waived,
+				RuleIDs: control.RuleIDs,
+			}
+		}
+		models[framework.Name] = model
+	}
+	return types.MapValueFrom(ctx, types.ObjectType{AttrTypes: complianceFrameworkAttrTypes}, models)
+}
+
+// writeCompliance adds the compliance roll-up to the formatted summary.
+func writeCompliance(formatted *strings.Builder, frameworks []FrameworkCompliance) {
+	if len(frameworks) == 0 {
+		return
+	}
+	formatted.WriteString("\nCompliance:\n")
+	formatted.WriteString("-----------\n")
+	for _, framework := range frameworks {
+		score := "not evaluated"
+		if framework.Evaluated() {
+			score = fmt.Sprintf("%.2f percent", framework.Percent())
+		}
+		formatted.WriteString(fmt.Sprintf("\n%s: %s (PASSED: %d FAILED: %d NOT EVALUATED: %d controls)\n",
+			framework.Name, score, framework.Passed, framework.Failed, framework.NotEvaluated))
+		for _, control := range framework.Controls {
+			formatted.WriteString(fmt.Sprintf("  %-13s %s (%s)\n", control.Status, control.ID, strings.Join(control.RuleIDs, ", ")))
+		}
+	}
+}
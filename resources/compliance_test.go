@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitControl(t *testing.T) {
+	tests := []struct {
+		control       string
+		families      []string
+		wantFramework string
+		wantID        string
+	}{
+		{"CIS-AWS-Compute-Services-Benchmark_v1.0.0_2.1.2", []string{"CIS-AWS-Compute-Services-Benchmark_v1.0.0"}, "CIS-AWS-Compute-Services-Benchmark_v1.0.0", "2.1.2"},
+		{"CIS-AWS-Compute-Services-Benchmark_v1.0.0_2.1.2", nil, "CIS-AWS-Compute-Services-Benchmark_v1.0.0", "2.1.2"},
+		{"NIST-800-53_vRev4_AC_2", []string{"NIST-800-53_vRev4"}, "NIST-800-53_vRev4", "AC_2"},
+		{"AC-2", nil, complianceOtherFramework, "AC-2"},
+	}
+	for _, tt := range tests {
+		framework, id := splitControl(tt.control, tt.families)
+		if framework != tt.wantFramework || id != tt.wantID {
+			t.Errorf("splitControl(%q, %v) = %q, %q, want %q, %q", tt.control, tt.families, framework, id, tt.wantFramework, tt.wantID)
+		}
+	}
+}
+
+func TestCalculateCompliance(t *testing.T) {
+	cis := []string{"CIS-AWS_v1.4.0"}
+	results := []RegulaRuleResult{
+		{RuleID: "S3.1", RuleResult: "PASS", Families: cis, Controls: []string{"CIS-AWS_v1.4.0_2.1.5"}},
+		{RuleID: "S3.1", RuleResult: "FAIL", Families: cis, Controls: []string{"CIS-AWS_v1.4.0_2.1.5"}},
+		{RuleID: "S3.2", RuleResult: "PASS", Families: cis, Controls: []string{"CIS-AWS_v1.4.0_2.1.1"}},
+		{RuleID: "S3.3", RuleResult: "PASS", Families: cis, Controls: []string{"CIS-AWS_v1.4.0_2.1.1"}},
+		{RuleID: "EC2.1", RuleResult: "WAIVED", Families: cis, Controls: []string{"CIS-AWS_v1.4.0_5.6"}},
+		{RuleID: "IAM.1", RuleResult: "PASS", Families: []string{"SOC2"}, Controls: []string{"SOC2_CC6.1"}},
+		{RuleID: "TAG.1", RuleResult: "FAIL"},
+	}
+
+	got := calculateCompliance(results)
+	want := []FrameworkCompliance{
+		{
+			Name: "CIS-AWS_v1.4.0",
+			Controls: []ControlCompliance{
+				{ID: "2.1.1", Status: ControlPass, Passed: 2, RuleIDs: []string{"S3.2", "S3.3"}},
+				{ID: "2.1.5", Status: ControlFail, Passed: 1, Failed: 1, RuleIDs: []string{"S3.1"}},
+				{ID: "5.6", Status: ControlNotEvaluated, Waived: 1, RuleIDs: []string{"EC2.1"}},
+			},
+			Passed:       1,
+			Failed:       1,
+			NotEvaluated: 1,
+		},
+		{
+			Name:     "SOC2",
+			Controls: []ControlCompliance{{ID: "CC6.1", Status: ControlPass, Passed: 1, RuleIDs: []string{"IAM.1"}}},
+			Passed:   1,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("calculateCompliance() = %+v, want %+v", got, want)
+	}
+	if got[0].Percent() != 50 || got[1].Percent() != 100 {
+		t.Errorf("Percent() = %v, %v, want 50, 100", got[0].Percent(), got[1].Percent())
+	}
+
+	value, diags := complianceValue(context.Background(), got)
+	if diags.HasError() {
+		t.Fatalf("complianceValue() diagnostics = %v", diags)
+	}
+	models := map[string]ComplianceFrameworkModel{}
+	if diags := value.ElementsAs(context.Background(), &models, false); diags.HasError() {
+		t.Fatalf("ElementsAs() diagnostics = %v", diags)
+	}
+	if cisModel := models["CIS-AWS_v1.4.0"]; cisModel.Score.ValueFloat64() != 50 || cisModel.Controls["2.1.5"].Status != ControlFail {
+		t.Errorf("complianceValue() CIS-AWS_v1.4.0 = %+v", cisModel)
+	}
+
+	var summary strings.Builder
+	writeCompliance(&summary, got)
+	for _, line := range []string{
+		"CIS-AWS_v1.4.0: 50.00 percent (PASSED: 1 FAILED: 1 NOT EVALUATED: 1 controls)",
+		"  FAIL          2.1.5 (S3.1)",
+		"  NOT_EVALUATED 5.6 (EC2.1)",
+	} {
+		if !strings.Contains(summary.String(), line+"\n") {
+			t.Errorf("writeCompliance() = %q, want line %q", summary.String(), line)
+		}
+	}
+}
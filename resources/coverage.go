@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Coverage reports the cloud resources of the IaC no rule was evaluated
+// against. Resources of other providers, e.g. random or null, are not counted.
+type Coverage struct {
+	// Resources is the number of cloud resources in the IaC.
+	Resources int64
+	// Covered is the number of cloud resources with at least one rule result.
+	Covered int64
+	// UncoveredResources are the addresses of the resources without rule results.
+	UncoveredResources []string
+	// UnmappedResourceTypes are the cloud resource types without a taxon.
+	UnmappedResourceTypes []string
+	// MissingTaxons are the taxons without a directory in the PAC repository.
+	MissingTaxons []string
+	// Filter describes the rule filter of the scan, empty when every rule was
+	// evaluated. Resources may be uncovered only because their rules were skipped.
+	Filter string
+}
+
+// Evaluated reports whether the IaC has cloud resources to cover.
+func (c Coverage) Evaluated() bool {
+	return c.Resources > 0
+}
+
+// Percent returns the share of covered resources, 0 when not evaluated.
+func (c Coverage) Percent() float64 {
+	if !c.Evaluated() {
+		return 0
+	}
+	return float64(c.Covered) / float64(c.Resources) * 100
+}
+
+// calculateCoverage matches the cloud resources with the resources the rule
+// results of the rules selected by filter were reported for.
+func calculateCoverage(resources []utils.TerraformResource, ruleResults []RegulaRuleResult, pac utils.PACCoverage, filter RuleFilter) Coverage {
+	evaluated := map[string]bool{}
+	for _, rule := range ruleResults {
+		evaluated[rule.ResourceID] = true
+	}
+
+	coverage := Coverage{
+		UncoveredResources:    []string{},
+		UnmappedResourceTypes: append([]string{}, pac.UnmappedResourceTypes...),
+		MissingTaxons:         append([]string{}, pac.MissingTaxons...),
+		Filter:                filter.String(),
+	}
+	seen := map[string]bool{}
+	for _, resource := range resources {
+		if !pac.IsCloudResource(resource.Type) || seen[resource.Address] {
+			continue
+		}
+		seen[resource.Address] = true
+		coverage.Resources++
+		if evaluated[resource.Address] {
+			coverage.Covered++
+		} else {
+			coverage.UncoveredResources = append(coverage.UncoveredResources, resource.Address)
+		}
+	}
+	sort.Strings(coverage.UncoveredResources)
+	return coverage
+}
+
+// CoverageModel describes the coverage attribute.
+type CoverageModel struct {
+	Percent               types.Float64 `tfsdk:"percent"`
+	ResourceCount         int64         `tfsdk:"resource_count"`
+	CoveredCount          int64         `tfsdk:"covered_count"`
+	UncoveredResources    []string      `tfsdk:"uncovered_resources"`
+	UnmappedResourceTypes []string      `tfsdk:"unmapped_resource_types"`
+	MissingTaxons         []string      `tfsdk:"missing_taxons"`
+}
+
+var coverageAttrTypes = map[string]attr.Type{
+	"percent":                 types.Float64Type,
+	"resource_count":          types.Int64Type,
+	"covered_count":           types.Int64Type,
+	"uncovered_resources":     types.ListType{ElemType: types.StringType},
+	"unmapped_resource_types": types.ListType{ElemType: types.StringType},
+	"missing_taxons":          types.ListType{ElemType: types.StringType},
+}
+
+// coverageValue converts the coverage into the coverage object value.
+func coverageValue(ctx context.Context, coverage Coverage) (types.Object, diag.Diagnostics) {
+	model := CoverageModel{
+		Percent:               types.Float64Null(),
+		ResourceCount:         coverage.Resources,
+		CoveredCount:          coverage.Covered,
+		UncoveredResources:    coverage.UncoveredResources,
+		UnmappedResourceTypes: coverage.UnmappedResourceTypes,
+		MissingTaxons:         coverage.MissingTaxons,
+	}
+	if coverage.Evaluated() {
+		model.Percent = types.Float64Value(coverage.Percent())
+	}
+	return types.ObjectValueFrom(ctx, coverageAttrTypes, model)
+}
+
+// gaps describes the uncovered resources and their causes, one per line.
+func (c Coverage) gaps() string {
+	var formatted strings.Builder
+	reason := "has no applicable rules"
+	if c.Filter != "" && len(c.UncoveredResources) > 0 {
+		formatted.WriteString(fmt.Sprintf("  - only the rules selected by the rule filter (%s) were evaluated\n", c.Filter))
+		reason = "has no applicable rules among the selected ones"
+	}
+	for _, address := range c.UncoveredResources {
+		formatted.WriteString(fmt.Sprintf("  - resource %s %s\n", address, reason))
+	}
+	for _, resourceType := range c.UnmappedResourceTypes {
+		formatted.WriteString(fmt.Sprintf("  - resource type %s is not mapped to a taxon\n", resourceType))
+	}
+	for _, taxon := range c.MissingTaxons {
+		formatted.WriteString(fmt.Sprintf("  - taxon directory %s is missing from the PAC repository\n", taxon))
+	}
+	return formatted.String()
+}
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+	"testing"
+)
+
+func TestCalculateCoverage(t *testing.T) {
+	resources := []utils.TerraformResource{
+		{Address: "aws_s3_bucket.logs", Type: "aws_s3_bucket"},
+		{Address: "aws_instance.web[0]", Type: "aws_instance"},
+		{Address: "aws_instance.web[1]", Type: "aws_instance"},
+		{Address: "google_tags_tag_key.env", Type: "google_tags_tag_key"},
+		{Address: "random_id.suffix", Type: "random_id"},
+	}
+	ruleResults := []RegulaRuleResult{
+		{RuleID: "S3.1", RuleResult: "PASS", ResourceID: "aws_s3_bucket.logs"},
+		{RuleID: "S3.2", RuleResult: "FAIL", ResourceID: "aws_s3_bucket.logs"},
+		{RuleID: "EC2.1", RuleResult: "WAIVED", ResourceID: "aws_instance.web[1]"},
+	}
+	pac := utils.PACCoverage{
+		UnmappedResourceTypes: []string{"google_tags_tag_key"},
+		MissingTaxons:         []string{"terraform/aws/Amazon Elastic Compute Cloud (EC2)"},
+	}
+
+	got := calculateCoverage(resources, ruleResults, pac, RuleFilter{})
+	want := Coverage{
+		Resources:             4,
+		Covered:               2,
+		UncoveredResources:    []string{"aws_instance.web[0]", "google_tags_tag_key.env"},
+		UnmappedResourceTypes: pac.UnmappedResourceTypes,
+		MissingTaxons:         pac.MissingTaxons,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("calculateCoverage() = %+v, want %+v", got, want)
+	}
+	if got.Percent() != 50 {
+		t.Errorf("Percent() = %v, want 50", got.Percent())
+	}
+
+	filtered := calculateCoverage(resources, ruleResults, pac, RuleFilter{MinSeverity: "High"})
+	if filtered.Filter != "min_severity: High" {
+		t.Errorf("calculateCoverage(filtered) filter = %q, want min_severity: High", filtered.Filter)
+	}
+
+	if empty := calculateCoverage(nil, ruleResults, utils.PACCoverage{}, RuleFilter{}); empty.Evaluated() {
+		t.Errorf("calculateCoverage(no resources) = %+v, want it not evaluated", empty)
+	}
+}
+
+func TestCheckResultMinCoverage(t *testing.T) {
+	ctx := context.Background()
+	result := ScanResult{Coverage: Coverage{
+		Resources:          4,
+		Covered:            3,
+		UncoveredResources: []string{"aws_instance.web"},
+	}}
+
+	tests := []struct {
+		name        string
+		minCoverage float64
+		coverage    *Coverage
+		wantError   string
+		wantWarning string
+	}{
+		{
+			name:        "met",
+			minCoverage: 75,
+		},
+		{
+			name:        "below minimum",
+			minCoverage: 80,
+			wantError:   "resource aws_instance.web has no applicable rules",
+		},
+		{
+			name:        "below minimum with a rule filter",
+			minCoverage: 80,
+			coverage: &Coverage{
+				Resources:          4,
+				Covered:            3,
+				UncoveredResources: []string{"aws_instance.web"},
+				Filter:             "min_severity: High",
+			},
+			wantError: "only the rules selected by the rule filter (min_severity: High) were evaluated\n" +
+				"  - resource aws_instance.web has no applicable rules among the selected ones",
+		},
+		{
+			name:        "no cloud resources",
+			minCoverage: 80,
+			coverage:    &Coverage{},
+			wantWarning: "Policy Coverage Not Evaluated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanned := result
+			if tt.coverage != nil {
+				scanned.Coverage = *tt.coverage
+			}
+			minCoverage := tt.minCoverage
+			diags := IACPACResourceModel{}.checkResult(ctx, ScanOptions{MinCoverage: &minCoverage}, scanned)
+			if tt.wantError == "" && diags.HasError() {
+				t.Errorf("checkResult() diagnostics = %v, want no error", diags)
+			}
+			if tt.wantError != "" && (!diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.wantError)) {
+				t.Errorf("checkResult() diagnostics = %v, want an error with %q", diags, tt.wantError)
+			}
+			if tt.wantWarning != "" && (diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != tt.wantWarning) {
+				t.Errorf("checkResult() diagnostics = %v, want the warning %q", diags, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-starchitect/resources/utils"
+)
+
+const (
+	// EngineNative evaluates the rules in-process with the embedded OPA evaluator.
+	EngineNative = "native"
+	// EngineRegula evaluates the rules with the regula executable.
+	EngineRegula = "regula"
+)
+
+const (
+	// InputTypeTerraform is the input type of Terraform configuration directories.
+	InputTypeTerraform = "tf"
+	// InputTypeTerraformPlan is the input type of plan JSON files.
+	InputTypeTerraformPlan = "tf_plan"
+)
+
+// Engines lists the supported values of the engine attribute.
+var Engines = []string{EngineNative, EngineRegula}
+
+// ScanInput is the IaC evaluated by a scan engine.
+type ScanInput struct {
+	// Path is a Terraform configuration directory or plan JSON file.
+	Path string
+	// Type is the regula input type of Path, reported in the rule results.
+	Type string
+	// Resources are the resources loaded from Path, see utils.LoadIaC.
+	// Engines parsing the IaC themselves ignore them.
+	Resources []utils.TerraformResource
+}
+
+// ScanEngine evaluates the rules found in pacPath against the IaC of input
+// and returns the results in the regula JSON output format.
+type ScanEngine interface {
+	Run(ctx context.Context, input ScanInput, pacPath string) ([]byte, error)
+}
+
+// newScanEngine returns the engine registered under name, the native engine by default.
+// executable overrides the executable run by external engines, filter selects
+// the rules evaluated.
+func newScanEngine(name, executable string, filter RuleFilter) (ScanEngine, error) {
+	switch name {
+	case "", EngineNative:
+		return &nativeEngine{filter: filter}, nil
+	case EngineRegula:
+		if executable == "" {
+			executable = "regula"
+		}
+		return &regulaEngine{executable: executable, filter: filter}, nil
+	}
+	return nil, fmt.Errorf("unknown scan engine %q", name)
+}
@@ -0,0 +1,412 @@
+package resources
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
+)
+
+//go:embed regolib/*.rego
+var regoLibrary embed.FS
+
+// nativeEngine evaluates Fugue style rules in-process with OPA.
+type nativeEngine struct {
+	filter RuleFilter
+}
+
+// regoMetadoc is the __rego__metadoc__ document of a rule.
+type regoMetadoc struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Custom      struct {
+		Controls map[string][]string `json:"controls"`
+		Severity string              `json:"severity"`
+	} `json:"custom"`
+}
+
+// nativeRule is a compiled rule package, e.g. data.rules.aws_ami_private
+type nativeRule struct {
+	path         ast.Ref
+	name         string
+	metadoc      regoMetadoc
+	resourceType string
+	hasDeny      bool
+	hasPolicy    bool
+}
+
+// judgement is a single entry of the policy set of a MULTIPLE rule.
+type judgement struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Valid   bool   `json:"valid"`
+	Message string `json:"message"`
+}
+
+func (e *nativeEngine) Run(ctx context.Context, input ScanInput, pacPath string) ([]byte, error) {
+	compiler, rules, err := compileRules(pacPath)
+	if err != nil {
+		return nil, err
+	}
+
+	output := RegulaOutput{RuleResults: []RegulaRuleResult{}}
+	for _, rule := range rules {
+		if err := rule.loadMetadata(ctx, compiler); err != nil {
+			return nil, err
+		}
+		if !e.filter.selects(rule.metadata()) {
+			continue
+		}
+		results, err := rule.evaluate(ctx, compiler, input)
+		if err != nil {
+			return nil, err
+		}
+		for i := range results {
+			results[i].InputType = input.Type
+		}
+		output.RuleResults = append(output.RuleResults, results...)
+	}
+	return json.Marshal(output)
+}
+
+// compileRules compiles every .rego file below pacPath together with the
+// embedded Fugue library and returns the rule packages found.
+func compileRules(pacPath string) (*ast.Compiler, []*nativeRule, error) {
+	modules := map[string]*ast.Module{}
+
+	libraryFiles, err := regoLibrary.ReadDir("regolib")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, file := range libraryFiles {
+		name := "regolib/" + file.Name()
+		content, err := regoLibrary.ReadFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		module, err := ast.ParseModule(name, string(content))
+		if err != nil {
+			return nil, nil, err
+		}
+		modules[name] = module
+	}
+
+	parseErrors := []error{}
+	err = filepath.Walk(pacPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		module, parseErr := ast.ParseModule(path, string(content))
+		if parseErr != nil {
+			parseErrors = append(parseErrors, parseErr)
+			return nil
+		}
+		modules[path] = module
+		return nil
+	})
+	if err != nil {
+		return nil, nil, &utils.PACFetchError{Source: pacPath, Err: err}
+	}
+	if len(parseErrors) > 0 {
+		compileErr := &RegoCompileError{}
+		for _, parseErr := range parseErrors {
+			compileErr.Problems = append(compileErr.Problems, newRegoCompileError(parseErr).Problems...)
+		}
+		return nil, nil, compileErr
+	}
+
+	compiler := ast.NewCompiler()
+	compiler.Compile(modules)
+	if compiler.Failed() {
+		return nil, nil, newRegoCompileError(compiler.Errors)
+	}
+
+	rulesByPath := map[string]*nativeRule{}
+	for _, module := range modules {
+		if !module.Package.Path.HasPrefix(ast.MustParseRef("data.rules")) {
+			continue
+		}
+		key := module.Package.Path.String()
+		rule, ok := rulesByPath[key]
+		if !ok {
+			rule = &nativeRule{
+				path: module.Package.Path,
+				name: strings.Trim(module.Package.Path[len(module.Package.Path)-1].String(), `"`),
+			}
+			rulesByPath[key] = rule
+		}
+		for _, regoRule := range module.Rules {
+			switch regoRule.Head.Ref()[0].String() {
+			case "deny":
+				rule.hasDeny = true
+			case "policy":
+				rule.hasPolicy = true
+			}
+		}
+	}
+
+	rules := make([]*nativeRule, 0, len(rulesByPath))
+	for _, rule := range rulesByPath {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].path.String() < rules[j].path.String()
+	})
+	return compiler, rules, nil
+}
+
+// query evaluates a document of the rule package, defined is false when the document is undefined.
+// The input type is exposed to the rules as data.scan.input_type, read by fugue.input_type.
+func (r *nativeRule) query(ctx context.Context, compiler *ast.Compiler, document string, input interface{}, inputType string) (interface{}, bool, error) {
+	options := []func(*rego.Rego){
+		rego.Compiler(compiler),
+		rego.Query(r.path.Append(ast.StringTerm(document)).String()),
+	}
+	if input != nil {
+		options = append(options, rego.Input(input))
+	}
+	if inputType != "" {
+		options = append(options, rego.Store(inmem.NewFromObject(map[string]interface{}{
+			"scan": map[string]interface{}{"input_type": inputType},
+		})))
+	}
+	resultSet, err := rego.New(options...).Eval(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to evaluate %s of rule %s: %v", document, r.name, err)
+	}
+	if len(resultSet) == 0 || len(resultSet[0].Expressions) == 0 {
+		return nil, false, nil
+	}
+	return resultSet[0].Expressions[0].Value, true, nil
+}
+
+func (r *nativeRule) loadMetadata(ctx context.Context, compiler *ast.Compiler) error {
+	metadoc, defined, err := r.query(ctx, compiler, "__rego__metadoc__", nil, "")
+	if err != nil {
+		return err
+	}
+	if defined {
+		content, err := json.Marshal(metadoc)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(content, &r.metadoc); err != nil {
+			return fmt.Errorf("invalid __rego__metadoc__ in rule %s: %v", r.name, err)
+		}
+	}
+
+	resourceType, defined, err := r.query(ctx, compiler, "resource_type", nil, "")
+	if err != nil {
+		return err
+	}
+	if value, ok := resourceType.(string); defined && ok {
+		r.resourceType = value
+	}
+	if r.resourceType == "" && r.hasPolicy {
+		r.resourceType = "MULTIPLE"
+	}
+	return nil
+}
+
+// metadata returns what rule filters match the rule by, once loadMetadata ran.
+func (r *nativeRule) metadata() ruleMetadata {
+	families := make([]string, 0, len(r.metadoc.Custom.Controls))
+	for family := range r.metadoc.Custom.Controls {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return ruleMetadata{
+		ID:       r.metadoc.ID,
+		Name:     r.name,
+		Severity: r.metadoc.Custom.Severity,
+		Families: families,
+	}
+}
+
+// selectRules returns the names of the rules in pacPath selected by the filter.
+func selectRules(ctx context.Context, pacPath string, filter RuleFilter) ([]string, error) {
+	compiler, rules, err := compileRules(pacPath)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, rule := range rules {
+		if err := rule.loadMetadata(ctx, compiler); err != nil {
+			return nil, err
+		}
+		if filter.selects(rule.metadata()) {
+			names = append(names, rule.name)
+		}
+	}
+	return names, nil
+}
+
+// resourceDocument builds the input document of a resource as regula does.
+func resourceDocument(resource utils.TerraformResource) map[string]interface{} {
+	document := map[string]interface{}{}
+	for key, value := range resource.Attributes {
+		document[key] = value
+	}
+	document["id"] = resource.Address
+	document["_type"] = resource.Type
+	document["_provider"] = resource.Provider
+	document["_filepath"] = resource.Filepath
+	document["_tags"] = resourceTags(resource)
+	return document
+}
+
+func resourceTags(resource utils.TerraformResource) map[string]string {
+	tags := map[string]string{}
+	if values, ok := resource.Attributes["tags"].(map[string]interface{}); ok {
+		for key, value := range values {
+			if text, ok := value.(string); ok {
+				tags[key] = text
+			}
+		}
+	}
+	return tags
+}
+
+func (r *nativeRule) evaluate(ctx context.Context, compiler *ast.Compiler, input ScanInput) ([]RegulaRuleResult, error) {
+	if r.resourceType == "MULTIPLE" {
+		return r.evaluateMultiple(ctx, compiler, input)
+	}
+
+	results := []RegulaRuleResult{}
+	for _, resource := range input.Resources {
+		if resource.Type != r.resourceType {
+			continue
+		}
+		valid, message, err := r.evaluateSingle(ctx, compiler, resourceDocument(resource), input.Type)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r.result(resource, resource.Type, valid, message))
+	}
+	return results, nil
+}
+
+// evaluateSingle evaluates a rule that checks one resource at a time through allow or deny.
+func (r *nativeRule) evaluateSingle(ctx context.Context, compiler *ast.Compiler, input map[string]interface{}, inputType string) (bool, string, error) {
+	if r.hasDeny {
+		deny, defined, err := r.query(ctx, compiler, "deny", input, inputType)
+		if err != nil || !defined {
+			return true, "", err
+		}
+		switch value := deny.(type) {
+		case bool:
+			return !value, "", nil
+		case []interface{}:
+			if len(value) == 0 {
+				return true, "", nil
+			}
+			message, _ := value[0].(string)
+			return false, message, nil
+		}
+		return true, "", nil
+	}
+
+	allow, defined, err := r.query(ctx, compiler, "allow", input, inputType)
+	if err != nil {
+		return false, "", err
+	}
+	value, ok := allow.(bool)
+	return defined && ok && value, "", nil
+}
+
+// evaluateMultiple evaluates a rule that judges all resources at once through its policy set.
+func (r *nativeRule) evaluateMultiple(ctx context.Context, compiler *ast.Compiler, input ScanInput) ([]RegulaRuleResult, error) {
+	byAddress := map[string]utils.TerraformResource{}
+	documents := map[string]interface{}{}
+	for _, resource := range input.Resources {
+		byAddress[resource.Address] = resource
+		documents[resource.Address] = resourceDocument(resource)
+	}
+
+	policy, defined, err := r.query(ctx, compiler, "policy", map[string]interface{}{"resources": documents}, input.Type)
+	if err != nil || !defined {
+		return []RegulaRuleResult{}, err
+	}
+	content, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	judgements := []judgement{}
+	if err := json.Unmarshal(content, &judgements); err != nil {
+		return nil, fmt.Errorf("invalid policy judgements in rule %s: %v", r.name, err)
+	}
+	sort.SliceStable(judgements, func(i, j int) bool {
+		return judgements[i].ID < judgements[j].ID
+	})
+
+	results := []RegulaRuleResult{}
+	for _, judgement := range judgements {
+		resource, ok := byAddress[judgement.ID]
+		if !ok {
+			resource = utils.TerraformResource{Address: judgement.ID, Type: judgement.Type}
+		}
+		results = append(results, r.result(resource, judgement.Type, judgement.Valid, judgement.Message))
+	}
+	return results, nil
+}
+
+func (r *nativeRule) result(resource utils.TerraformResource, resourceType string, valid bool, message string) RegulaRuleResult {
+	families := []string{}
+	controls := []string{}
+	for family, familyControls := range r.metadoc.Custom.Controls {
+		families = append(families, family)
+		controls = append(controls, familyControls...)
+	}
+	sort.Strings(families)
+	sort.Strings(controls)
+
+	ruleResult := "FAIL"
+	if valid {
+		ruleResult = "PASS"
+	}
+	severity := r.metadoc.Custom.Severity
+	if severity == "" {
+		severity = severityUnknown
+	}
+
+	return RegulaRuleResult{
+		Controls:        controls,
+		Families:        families,
+		Filepath:        resource.Filepath,
+		Provider:        resource.Provider,
+		ResourceID:      resource.Address,
+		ResourceType:    resourceType,
+		ResourceTags:    resourceTags(resource),
+		RuleDescription: r.metadoc.Description,
+		RuleID:          r.metadoc.ID,
+		RuleMessage:     message,
+		RuleName:        r.name,
+		RuleRawResult:   valid,
+		RuleResult:      ruleResult,
+		RuleSeverity:    severity,
+		RuleSummary:     r.metadoc.Title,
+		SourceLocation: []RegulaSourceLocation{{
+			Path:    resource.Filepath,
+			Line:    resource.StartLine,
+			Column:  1,
+			EndLine: resource.EndLine,
+		}},
+	}
+}
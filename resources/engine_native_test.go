@@ -0,0 +1,211 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"terraform-provider-starchitect/resources/utils"
+	"testing"
+)
+
+// testScanInput loads the IaC at path like GetScanResult does.
+func testScanInput(t *testing.T, path, inputType string) ScanInput {
+	t.Helper()
+	resources, err := utils.LoadIaC(path)
+	if err != nil {
+		t.Fatalf("LoadIaC(%s) error = %v", path, err)
+	}
+	return ScanInput{Path: path, Type: inputType, Resources: resources}
+}
+
+func TestNativeEngineRun(t *testing.T) {
+	iacDir := t.TempDir()
+	pacDir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(iacDir, "main.tf"): `
+resource "aws_s3_bucket" "public" {
+  acl = "public-read"
+}
+
+resource "aws_s3_bucket" "private" {
+  acl = "private"
+}
+`,
+		filepath.Join(pacDir, "s3_private.rego"): `
+package rules.s3_private
+
+__rego__metadoc__ := {
+	"id": "S3.1",
+	"title": "S3 buckets should be private",
+	"description": "S3 buckets must not grant public ACLs.",
+	"custom": {"controls": {"CIS": ["CIS_1.1"]}, "severity": "High"},
+}
+
+resource_type := "aws_s3_bucket"
+
+default allow = false
+
+allow {
+	input.acl == "private"
+}
+`,
+		filepath.Join(pacDir, "s3_deny.rego"): `
+package rules.s3_deny
+
+__rego__metadoc__ := {"id": "S3.2", "title": "deny public", "description": "", "custom": {"severity": "Low"}}
+
+resource_type := "aws_s3_bucket"
+
+deny[msg] {
+	input.acl == "public-read"
+	msg := "bucket is public"
+}
+`,
+		filepath.Join(pacDir, "s3_multiple.rego"): `
+package rules.s3_multiple
+
+import data.fugue
+
+__rego__metadoc__ := {"id": "S3.3", "title": "multiple", "description": "", "custom": {"severity": "Medium"}}
+
+resource_type := "MULTIPLE"
+
+buckets := fugue.resources("aws_s3_bucket")
+
+policy[p] {
+	bucket := buckets[_]
+	bucket.acl == "private"
+	p := fugue.allow_resource(bucket)
+}
+
+policy[p] {
+	bucket := buckets[_]
+	bucket.acl != "private"
+	p := fugue.deny_resource_with_message(bucket, "not private")
+}
+`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := (&nativeEngine{}).Run(context.Background(), testScanInput(t, iacDir, InputTypeTerraform), pacDir)
+	if err != nil {
+		t.Fatalf("nativeEngine.Run() error = %v", err)
+	}
+	var output RegulaOutput
+	if err := json.Unmarshal(content, &output); err != nil {
+		t.Fatalf("nativeEngine.Run() returned invalid JSON: %v", err)
+	}
+
+	got := map[string]RegulaRuleResult{}
+	for _, result := range output.RuleResults {
+		got[result.RuleID+" "+result.ResourceID] = result
+	}
+	want := map[string]struct {
+		result  string
+		message string
+	}{
+		"S3.1 aws_s3_bucket.private": {"PASS", ""},
+		"S3.1 aws_s3_bucket.public":  {"FAIL", ""},
+		"S3.2 aws_s3_bucket.private": {"PASS", ""},
+		"S3.2 aws_s3_bucket.public":  {"FAIL", "bucket is public"},
+		"S3.3 aws_s3_bucket.private": {"PASS", ""},
+		"S3.3 aws_s3_bucket.public":  {"FAIL", "not private"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("nativeEngine.Run() returned %d results, want %d", len(got), len(want))
+	}
+	for key, expected := range want {
+		result, ok := got[key]
+		if !ok {
+			t.Errorf("nativeEngine.Run() is missing result %s", key)
+			continue
+		}
+		if result.RuleResult != expected.result || result.RuleMessage != expected.message {
+			t.Errorf("%s = %s %q, want %s %q", key, result.RuleResult, result.RuleMessage, expected.result, expected.message)
+		}
+	}
+
+	s31 := got["S3.1 aws_s3_bucket.public"]
+	if s31.RuleName != "s3_private" || s31.RuleSeverity != "High" || s31.Families[0] != "CIS" || s31.Controls[0] != "CIS_1.1" {
+		t.Errorf("S3.1 metadata = %+v", s31)
+	}
+}
+
+func TestNativeEngineRunPlan(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	plan := `{
+  "format_version": "1.2",
+  "planned_values": {"root_module": {"child_modules": [{"address": "module.storage", "resources": [
+    {"address": "module.storage.aws_s3_bucket.this[\"logs\"]", "mode": "managed", "type": "aws_s3_bucket", "name": "this",
+     "provider_name": "registry.terraform.io/hashicorp/aws", "values": {"acl": "public-read"}},
+    {"address": "module.storage.aws_s3_bucket.this[\"data\"]", "mode": "managed", "type": "aws_s3_bucket", "name": "this",
+     "provider_name": "registry.terraform.io/hashicorp/aws", "values": {"acl": "private"}}
+  ]}]}}
+}`
+	rule := `
+package rules.s3_private
+
+import data.fugue
+
+__rego__metadoc__ := {"id": "S3.1", "title": "private", "description": "", "custom": {"severity": "High"}}
+
+resource_type := "aws_s3_bucket"
+
+default allow = false
+
+allow {
+	fugue.input_type == "tf_plan"
+	input.acl == "private"
+}
+`
+	if err := os.WriteFile(planPath, []byte(plan), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "s3_private.rego"), []byte(rule), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := (&nativeEngine{}).Run(context.Background(), testScanInput(t, planPath, InputTypeTerraformPlan), dir)
+	if err != nil {
+		t.Fatalf("nativeEngine.Run() error = %v", err)
+	}
+	var output RegulaOutput
+	if err := json.Unmarshal(content, &output); err != nil {
+		t.Fatalf("nativeEngine.Run() returned invalid JSON: %v", err)
+	}
+	got := map[string]string{}
+	for _, result := range output.RuleResults {
+		got[result.ResourceID] = result.RuleResult
+		if result.InputType != InputTypeTerraformPlan {
+			t.Errorf("%s input type = %s, want %s", result.ResourceID, result.InputType, InputTypeTerraformPlan)
+		}
+	}
+	want := map[string]string{
+		`module.storage.aws_s3_bucket.this["logs"]`: "FAIL",
+		`module.storage.aws_s3_bucket.this["data"]`: "PASS",
+	}
+	if len(got) != len(want) {
+		t.Errorf("nativeEngine.Run() = %v, want %v", got, want)
+	}
+	for address, result := range want {
+		if got[address] != result {
+			t.Errorf("%s = %s, want %s", address, got[address], result)
+		}
+	}
+}
+
+func TestNativeEngineRunCompileError(t *testing.T) {
+	pacDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pacDir, "broken.rego"), []byte("package rules.broken\n\nallow { undefined_function(input) }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&nativeEngine{}).Run(context.Background(), testScanInput(t, "../testdata/valid_iac", InputTypeTerraform), pacDir); err == nil {
+		t.Errorf("nativeEngine.Run() with an invalid rule did not fail")
+	}
+}
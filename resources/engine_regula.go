@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// regulaEngine runs the scan with the regula executable.
+type regulaEngine struct {
+	executable string
+	filter     RuleFilter
+}
+
+// regulaInputTypes maps the input types to the values of the regula --input-type flag.
+var regulaInputTypes = map[string]string{
+	InputTypeTerraform:     "tf",
+	InputTypeTerraformPlan: "tf-plan",
+}
+
+func (e *regulaEngine) Run(ctx context.Context, input ScanInput, pacPath string) ([]byte, error) {
+	executable, err := exec.LookPath(e.executable)
+	if err != nil {
+		return nil, &EngineNotFoundError{Engine: EngineRegula, Executable: e.executable, Err: err}
+	}
+
+	args := []string{"run", "-i", pacPath, input.Path, "-n", "-f", "json"}
+	if inputType, ok := regulaInputTypes[input.Type]; ok {
+		args = append(args, "-t", inputType)
+	}
+
+	// regula selects rules by ID or name only, the rules matching the filter
+	// are read with the native compiler and passed through --only
+	if !e.filter.IsEmpty() {
+		names, err := selectRules(ctx, pacPath, e.filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return json.Marshal(RegulaOutput{RuleResults: []RegulaRuleResult{}})
+		}
+		for _, name := range names {
+			args = append(args, "--only", name)
+		}
+	}
+
+	var stderr bytes.Buffer
+	tempDir, err := os.MkdirTemp("", "regula-scan")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create the output file path in the temporary directory
+	outputFile := filepath.Join(tempDir, "results.json")
+
+	cmd := exec.CommandContext(ctx, executable, args...)
+
+	// Redirect the output to the temporary file
+	output, err := os.Create(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("error creating output file: %v", err)
+	}
+	defer output.Close()
+
+	cmd.Stdout = output
+	cmd.Stderr = &stderr
+
+	// regula exits with a non-zero code when rules fail, so the exit code alone
+	// does not tell whether the scan itself failed
+	runErr := cmd.Run()
+	if runErr != nil && regulaProblemRegex.Match(stderr.Bytes()) {
+		return nil, &RegoCompileError{Problems: parseRegulaProblems(stderr.String())}
+	}
+
+	// Read the raw output
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading output file: %s %v", outputFile, err)
+	}
+	if runErr != nil && !json.Valid(content) {
+		return nil, fmt.Errorf("regula failed: %v %s", runErr, stderr.String())
+	}
+	return content, nil
+}
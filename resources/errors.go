@@ -0,0 +1,133 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/open-policy-agent/opa/ast"
+)
+
+// EngineNotFoundError is returned when the executable of a scan engine cannot be found.
+type EngineNotFoundError struct {
+	Engine     string
+	Executable string
+	Err        error
+}
+
+func (e *EngineNotFoundError) Error() string {
+	return fmt.Sprintf("%s executable %q not found: %v", e.Engine, e.Executable, e.Err)
+}
+
+func (e *EngineNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// RegoCompileError is returned when the policies cannot be parsed or compiled.
+type RegoCompileError struct {
+	Problems []utils.SourceProblem
+}
+
+func (e *RegoCompileError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, problem.String())
+	}
+	return fmt.Sprintf("failed to compile policies: %s", strings.Join(messages, "; "))
+}
+
+// newRegoCompileError converts the errors reported by the OPA parser and compiler.
+func newRegoCompileError(err error) *RegoCompileError {
+	compileErr := &RegoCompileError{}
+	var astErrors ast.Errors
+	if !errors.As(err, &astErrors) {
+		compileErr.Problems = append(compileErr.Problems, utils.SourceProblem{Message: err.Error()})
+		return compileErr
+	}
+	for _, astErr := range astErrors {
+		problem := utils.SourceProblem{Message: fmt.Sprintf("%s: %s", astErr.Code, astErr.Message)}
+		if astErr.Location != nil {
+			problem.File = astErr.Location.File
+			problem.Line = astErr.Location.Row
+			problem.Column = astErr.Location.Col
+		}
+		compileErr.Problems = append(compileErr.Problems, problem)
+	}
+	return compileErr
+}
+
+// regulaProblemRegex matches the rego errors printed by regula, e.g.
+// rules/s3.rego:12: rego_type_error: undefined function
+var regulaProblemRegex = regexp.MustCompile(`(?m)^(.+?):(\d+): (rego_\w+_error: .*)$`)
+
+// parseRegulaProblems extracts the rego errors from the stderr of regula.
+func parseRegulaProblems(stderr string) []utils.SourceProblem {
+	problems := []utils.SourceProblem{}
+	for _, match := range regulaProblemRegex.FindAllStringSubmatch(stderr, -1) {
+		line, _ := strconv.Atoi(match[2])
+		problems = append(problems, utils.SourceProblem{
+			File:    strings.TrimSpace(match[1]),
+			Line:    line,
+			Message: match[3],
+		})
+	}
+	if len(problems) == 0 {
+		problems = append(problems, utils.SourceProblem{Message: strings.TrimSpace(stderr)})
+	}
+	return problems
+}
+
+// scanErrorDiagnostics converts a scan error into diagnostics on the attribute causing it.
+func scanErrorDiagnostics(err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var engineErr *EngineNotFoundError
+	var compileErr *RegoCompileError
+	var parseErr *utils.IaCParseError
+	var fetchErr *utils.PACFetchError
+	var verifyErr *utils.PACVerificationError
+	var taxonomyErr *utils.TaxonomyError
+	var collisionErr *utils.PolicyCollisionError
+	switch {
+	case errors.As(err, &engineErr):
+		diags.AddAttributeError(
+			path.Root("engine"),
+			"Scan Engine Not Found",
+			fmt.Sprintf("%v. Install %s or use the %s engine.", engineErr, engineErr.Executable, EngineNative),
+		)
+	case errors.As(err, &compileErr):
+		for _, problem := range compileErr.Problems {
+			diags.AddAttributeError(path.Root("pac_path"), "Policy Compile Error", problem.String())
+		}
+	case errors.As(err, &parseErr):
+		attribute := path.Root("iac_path")
+		if parseErr.Plan {
+			attribute = path.Root("plan_json_path")
+		}
+		for _, problem := range parseErr.Problems {
+			diags.AddAttributeError(attribute, "IaC Parse Error", problem.String())
+		}
+	case errors.As(err, &taxonomyErr):
+		attribute := path.Root("pac_repository")
+		if taxonomyErr.Override {
+			attribute = path.Root("taxonomy_file")
+		}
+		diags.AddAttributeError(attribute, "Invalid Taxonomy", taxonomyErr.Error())
+	case errors.As(err, &collisionErr):
+		for _, collision := range collisionErr.Collisions {
+			diags.AddAttributeError(path.Root("pac_repository"), "Policy Collision", collision.String())
+		}
+	case errors.As(err, &verifyErr):
+		diags.AddAttributeError(path.Root("pac_verification"), "Policy Verification Failed", verifyErr.Error())
+	case errors.As(err, &fetchErr):
+		diags.AddAttributeError(path.Root("pac_repository"), "Policy Fetch Error", fetchErr.Error())
+	default:
+		diags.AddError("Scan Failed", err.Error())
+	}
+	return diags
+}
@@ -0,0 +1,184 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"terraform-provider-starchitect/resources/utils"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestParseRegulaProblems(t *testing.T) {
+	stderr := "2 errors occurred:\n" +
+		"rules/s3.rego:12: rego_type_error: undefined function data.fugue.missing\n" +
+		"rules/ec2.rego:3: rego_parse_error: unexpected eof token\n"
+
+	problems := parseRegulaProblems(stderr)
+	if len(problems) != 2 {
+		t.Fatalf("parseRegulaProblems() returned %d problems, want 2", len(problems))
+	}
+	want := utils.SourceProblem{File: "rules/s3.rego", Line: 12, Message: "rego_type_error: undefined function data.fugue.missing"}
+	if problems[0] != want {
+		t.Errorf("parseRegulaProblems()[0] = %+v, want %+v", problems[0], want)
+	}
+	if problems[1].String() != "rules/ec2.rego:3: rego_parse_error: unexpected eof token" {
+		t.Errorf("parseRegulaProblems()[1] = %q", problems[1].String())
+	}
+
+	problems = parseRegulaProblems("fatal error\n")
+	if len(problems) != 1 || problems[0].Message != "fatal error" {
+		t.Errorf("parseRegulaProblems() without locations = %+v", problems)
+	}
+}
+
+func TestScanErrorDiagnostics(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		path    path.Path
+		summary string
+		count   int
+	}{
+		{
+			name:    "engine not found",
+			err:     &EngineNotFoundError{Engine: EngineRegula, Executable: "regula", Err: errors.New("not found")},
+			path:    path.Root("engine"),
+			summary: "Scan Engine Not Found",
+			count:   1,
+		},
+		{
+			name: "rego compile error",
+			err: &RegoCompileError{Problems: []utils.SourceProblem{
+				{File: "a.rego", Line: 1, Message: "first"},
+				{File: "b.rego", Line: 2, Message: "second"},
+			}},
+			path:    path.Root("pac_path"),
+			summary: "Policy Compile Error",
+			count:   2,
+		},
+		{
+			name:    "IaC parse error",
+			err:     &utils.IaCParseError{Problems: []utils.SourceProblem{{File: "main.tf", Line: 3, Message: "bad"}}},
+			path:    path.Root("iac_path"),
+			summary: "IaC Parse Error",
+			count:   1,
+		},
+		{
+			name:    "plan parse error",
+			err:     &utils.IaCParseError{Problems: []utils.SourceProblem{{File: "plan.json", Message: "not a Terraform plan"}}, Plan: true},
+			path:    path.Root("plan_json_path"),
+			summary: "IaC Parse Error",
+			count:   1,
+		},
+		{
+			name:    "override taxonomy error",
+			err:     &utils.TaxonomyError{File: "taxonomy.yaml", Override: true, Err: errors.New("version is missing")},
+			path:    path.Root("taxonomy_file"),
+			summary: "Invalid Taxonomy",
+			count:   1,
+		},
+		{
+			name:    "rule pack taxonomy error",
+			err:     &utils.TaxonomyError{File: "/cache/rules/taxonomy.yaml", Err: errors.New("version is missing")},
+			path:    path.Root("pac_repository"),
+			summary: "Invalid Taxonomy",
+			count:   1,
+		},
+		{
+			name: "policy collision error",
+			err: &utils.PolicyCollisionError{Collisions: []utils.PolicyCollision{
+				{Package: "data.rules.s3_v1", Files: []string{"terraform/aws/s3/s3_v1.rego", "terraform/aws/s3_legacy/s3_v1.rego"}},
+				{Package: "data.lib.tags", Files: []string{"lib/tags.rego", "terraform/lib/tags.rego"}},
+			}},
+			path:    path.Root("pac_repository"),
+			summary: "Policy Collision",
+			count:   2,
+		},
+		{
+			name:    "PAC fetch error",
+			err:     &utils.PACFetchError{Source: "https://example.com/rules", Err: errors.New("unreachable")},
+			path:    path.Root("pac_repository"),
+			summary: "Policy Fetch Error",
+			count:   1,
+		},
+		{
+			name:    "PAC verification error",
+			err:     &utils.PACVerificationError{Source: "https://example.com/rules", Err: errors.New("s3.rego does not match its checksum")},
+			path:    path.Root("pac_verification"),
+			summary: "Policy Verification Failed",
+			count:   1,
+		},
+		{
+			name:    "other error",
+			err:     errors.New("boom"),
+			path:    path.Empty(),
+			summary: "Scan Failed",
+			count:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := scanErrorDiagnostics(tt.err)
+			if len(diags) != tt.count || diags.ErrorsCount() != tt.count {
+				t.Fatalf("scanErrorDiagnostics() = %v, want %d errors", diags, tt.count)
+			}
+			if diags[0].Summary() != tt.summary {
+				t.Errorf("scanErrorDiagnostics() summary = %q, want %q", diags[0].Summary(), tt.summary)
+			}
+			withPath, ok := diags[0].(interface{ Path() path.Path })
+			if tt.path.Equal(path.Empty()) {
+				if ok {
+					t.Errorf("scanErrorDiagnostics() attached the error to %s", withPath.Path())
+				}
+				return
+			}
+			if !ok || !withPath.Path().Equal(tt.path) {
+				t.Errorf("scanErrorDiagnostics() is not attached to %s", tt.path)
+			}
+		})
+	}
+}
+
+func TestGetScanResultErrors(t *testing.T) {
+	iacPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(iacPath, "main.tf"), []byte("resource \"aws_s3_bucket\" \"b\" {\n  bucket = \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := GetScanResult(context.Background(), ScanOptions{IACPath: iacPath, PACPath: "../testdata/valid_pac", LogPath: t.TempDir()})
+	var parseErr *utils.IaCParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("GetScanResult() error = %v, want IaCParseError", err)
+	}
+	if len(parseErr.Problems) == 0 || parseErr.Problems[0].Line == 0 {
+		t.Errorf("IaCParseError has no location: %+v", parseErr.Problems)
+	}
+
+	_, err = GetScanResult(context.Background(), ScanOptions{PlanJSONPath: filepath.Join(iacPath, "main.tf"), PACPath: "../testdata/valid_pac", LogPath: t.TempDir()})
+	if !errors.As(err, &parseErr) || !parseErr.Plan {
+		t.Errorf("GetScanResult() of an invalid plan error = %v, want an IaCParseError of the plan", err)
+	}
+
+	pacPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pacPath, "broken.rego"), []byte("package rules.broken\n\nallow {\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = GetScanResult(context.Background(), ScanOptions{IACPath: "../testdata/valid_iac", PACPath: pacPath, LogPath: t.TempDir()})
+	var compileErr *RegoCompileError
+	if !errors.As(err, &compileErr) {
+		t.Fatalf("GetScanResult() error = %v, want RegoCompileError", err)
+	}
+	if len(compileErr.Problems) == 0 || compileErr.Problems[0].Line == 0 {
+		t.Errorf("RegoCompileError has no location: %+v", compileErr.Problems)
+	}
+
+	_, err = GetScanResult(context.Background(), ScanOptions{IACPath: "../testdata/valid_iac", PACPath: pacPath, Engine: EngineRegula, LogPath: t.TempDir()})
+	var engineErr *EngineNotFoundError
+	if _, lookErr := exec.LookPath("regula"); lookErr != nil && !errors.As(err, &engineErr) {
+		t.Errorf("GetScanResult() error = %v, want EngineNotFoundError", err)
+	}
+}
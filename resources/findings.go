@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// FindingModel describes a single rule evaluation in the findings attribute.
+type FindingModel struct {
+	RuleID          string            `tfsdk:"rule_id"`
+	RuleName        string            `tfsdk:"rule_name"`
+	RuleSummary     string            `tfsdk:"rule_summary"`
+	RuleDescription string            `tfsdk:"rule_description"`
+	RuleResult      string            `tfsdk:"rule_result"`
+	RuleRawResult   bool              `tfsdk:"rule_raw_result"`
+	RuleSeverity    string            `tfsdk:"rule_severity"`
+	ResourceID      string            `tfsdk:"resource_id"`
+	ResourceType    string            `tfsdk:"resource_type"`
+	Filepath        string            `tfsdk:"filepath"`
+	StartLine       int64             `tfsdk:"start_line"`
+	EndLine         int64             `tfsdk:"end_line"`
+	InputType       string            `tfsdk:"input_type"`
+	Provider        string            `tfsdk:"provider"`
+	Controls        []string          `tfsdk:"controls"`
+	Families        []string          `tfsdk:"families"`
+	Message         string            `tfsdk:"message"`
+	Tags            map[string]string `tfsdk:"tags"`
+}
+
+// findingAttrTypes mirrors FindingModel for building list values.
+var findingAttrTypes = map[string]attr.Type{
+	"rule_id":          types.StringType,
+	"rule_name":        types.StringType,
+	"rule_summary":     types.StringType,
+	"rule_description": types.StringType,
+	"rule_result":      types.StringType,
+	"rule_raw_result":  types.BoolType,
+	"rule_severity":    types.StringType,
+	"resource_id":      types.StringType,
+	"resource_type":    types.StringType,
+	"filepath":         types.StringType,
+	"start_line":       types.Int64Type,
+	"end_line":         types.Int64Type,
+	"input_type":       types.StringType,
+	"provider":         types.StringType,
+	"controls":         types.ListType{ElemType: types.StringType},
+	"families":         types.ListType{ElemType: types.StringType},
+	"message":          types.StringType,
+	"tags":             types.MapType{ElemType: types.StringType},
+}
+
+func toFindingModels(ruleResults []RegulaRuleResult) []FindingModel {
+	findings := make([]FindingModel, 0, len(ruleResults))
+	for _, rule := range ruleResults {
+		_, startLine, endLine := rule.location()
+		finding := FindingModel{
+			RuleID:          rule.RuleID,
+			RuleName:        rule.RuleName,
+			RuleSummary:     rule.RuleSummary,
+			RuleDescription: rule.RuleDescription,
+			RuleResult:      rule.RuleResult,
+			RuleRawResult:   rule.RuleRawResult,
+			RuleSeverity:    rule.RuleSeverity,
+			ResourceID:      rule.ResourceID,
+			ResourceType:    rule.ResourceType,
+			Filepath:        rule.Filepath,
+			StartLine:       int64(startLine),
+			EndLine:         int64(endLine),
+			InputType:       rule.InputType,
+			Provider:        rule.Provider,
+			Controls:        append([]string{}, rule.Controls...),
+			Families:        append([]string{}, rule.Families...),
+			Message:         rule.RuleMessage,
+			Tags:            map[string]string{},
+		}
+		for key, value := range rule.ResourceTags {
+			finding.Tags[key] = value
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// findingsValue converts the regula rule results into the findings list value.
+func findingsValue(ctx context.Context, ruleResults []RegulaRuleResult) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: findingAttrTypes}, toFindingModels(ruleResults))
+}
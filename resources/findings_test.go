@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestToFindingModels(t *testing.T) {
+	ruleResults := []RegulaRuleResult{
+		{
+			Controls:     []string{"CIS-AWS-Compute-Services-Benchmark_v1.0.0_2.1.2"},
+			Filepath:     "main.tf",
+			ResourceID:   "aws_ami.example",
+			ResourceType: "aws_ami",
+			ResourceTags: map[string]string{"env": "dev"},
+			RuleID:       "2.1.2",
+			RuleMessage:  "AMI must be encrypted",
+			RuleResult:   "FAIL",
+			RuleSeverity: "High",
+			SourceLocation: []RegulaSourceLocation{
+				{Path: "main.tf", Line: 12, Column: 1, EndLine: 18},
+			},
+		},
+	}
+
+	got := toFindingModels(ruleResults)
+	want := []FindingModel{
+		{
+			RuleID:       "2.1.2",
+			RuleResult:   "FAIL",
+			RuleSeverity: "High",
+			ResourceID:   "aws_ami.example",
+			ResourceType: "aws_ami",
+			Filepath:     "main.tf",
+			StartLine:    12,
+			EndLine:      18,
+			Controls:     []string{"CIS-AWS-Compute-Services-Benchmark_v1.0.0_2.1.2"},
+			Families:     []string{},
+			Message:      "AMI must be encrypted",
+			Tags:         map[string]string{"env": "dev"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toFindingModels() = %v, want %v", got, want)
+	}
+
+	findings, diags := findingsValue(context.Background(), ruleResults)
+	if diags.HasError() {
+		t.Fatalf("findingsValue() diagnostics = %v", diags)
+	}
+	if len(findings.Elements()) != 1 {
+		t.Errorf("findingsValue() elements = %d, want 1", len(findings.Elements()))
+	}
+}
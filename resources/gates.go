@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FailOnGate holds the failures allowed per severity and the rules that must never fail.
+type FailOnGate struct {
+	// MaxFailures is the number of FAIL results allowed per normalized severity.
+	// Severities without an entry are not limited.
+	MaxFailures map[string]int64
+	// RuleIDs are rules of which any FAIL result trips the gate.
+	RuleIDs []string
+}
+
+// GateViolation describes why a gate tripped and which findings caused it.
+type GateViolation struct {
+	Reason   string
+	Findings []RegulaRuleResult
+}
+
+// Evaluate returns the violations of the gate, ordered by severity and then by rule ID.
+func (g FailOnGate) Evaluate(ruleResults []RegulaRuleResult) []GateViolation {
+	failedBySeverity := map[string][]RegulaRuleResult{}
+	failedByRule := map[string][]RegulaRuleResult{}
+	for _, rule := range ruleResults {
+		if rule.RuleResult != "FAIL" {
+			continue
+		}
+		severity := normalizeSeverity(rule.RuleSeverity)
+		failedBySeverity[severity] = append(failedBySeverity[severity], rule)
+		failedByRule[rule.RuleID] = append(failedByRule[rule.RuleID], rule)
+	}
+
+	violations := []GateViolation{}
+	for _, severity := range append(Severities, severityUnknown) {
+		maxFailures, ok := g.MaxFailures[severity]
+		if !ok {
+			continue
+		}
+		failed := failedBySeverity[severity]
+		if int64(len(failed)) > maxFailures {
+			violations = append(violations, GateViolation{
+				Reason:   fmt.Sprintf("%d %s failures exceed the allowed maximum of %d", len(failed), severity, maxFailures),
+				Findings: failed,
+			})
+		}
+	}
+	for _, ruleID := range g.RuleIDs {
+		if failed := failedByRule[ruleID]; len(failed) > 0 {
+			violations = append(violations, GateViolation{
+				Reason:   fmt.Sprintf("rule %s failed %d time(s)", ruleID, len(failed)),
+				Findings: failed,
+			})
+		}
+	}
+	return violations
+}
+
+// String renders the violation with one line per finding.
+func (v GateViolation) String() string {
+	var formatted strings.Builder
+	formatted.WriteString(v.Reason)
+	formatted.WriteString(":\n")
+	for _, rule := range v.Findings {
+		formatted.WriteString(fmt.Sprintf("  - [%s] %s %s: %s", rule.RuleSeverity, rule.RuleID, rule.RuleName, rule.ResourceID))
+		if file, line, _ := rule.location(); file != "" && line > 0 {
+			formatted.WriteString(fmt.Sprintf(" (%s:%d)", file, line))
+		} else if file != "" {
+			formatted.WriteString(fmt.Sprintf(" (%s)", file))
+		}
+		if rule.RuleMessage != "" {
+			formatted.WriteString(fmt.Sprintf(" %s", rule.RuleMessage))
+		}
+		formatted.WriteString("\n")
+	}
+	return formatted.String()
+}
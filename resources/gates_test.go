@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFailOnGateEvaluate(t *testing.T) {
+	ruleResults := []RegulaRuleResult{
+		{RuleID: "2.1.2", RuleResult: "FAIL", RuleSeverity: "High", ResourceID: "aws_ami.a"},
+		{RuleID: "2.1.5", RuleResult: "FAIL", RuleSeverity: "Medium", ResourceID: "aws_ami.a"},
+		{RuleID: "2.1.5", RuleResult: "FAIL", RuleSeverity: "Medium", ResourceID: "aws_ami.b"},
+		{RuleID: "2.1.5", RuleResult: "PASS", RuleSeverity: "Medium", ResourceID: "aws_ami.c"},
+		{RuleID: "AutoScaling.1", RuleResult: "WAIVED", RuleSeverity: "Critical", ResourceID: "aws_autoscaling_group.a"},
+	}
+
+	tests := []struct {
+		name        string
+		gate        FailOnGate
+		wantReasons []string
+	}{
+		{
+			name: "within limits",
+			gate: FailOnGate{MaxFailures: map[string]int64{"Critical": 0, "High": 1, "Medium": 2}},
+		},
+		{
+			name: "severity limits exceeded",
+			gate: FailOnGate{MaxFailures: map[string]int64{"High": 0, "Medium": 1, "Low": 0}},
+			wantReasons: []string{
+				"1 High failures exceed the allowed maximum of 0",
+				"2 Medium failures exceed the allowed maximum of 1",
+			},
+		},
+		{
+			name: "rule ids",
+			gate: FailOnGate{RuleIDs: []string{"2.1.5", "AutoScaling.1"}},
+			wantReasons: []string{
+				"rule 2.1.5 failed 2 time(s)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := []string{}
+			for _, violation := range tt.gate.Evaluate(ruleResults) {
+				reasons = append(reasons, violation.Reason)
+			}
+			if tt.wantReasons == nil {
+				tt.wantReasons = []string{}
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("FailOnGate.Evaluate() = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestGateViolationString(t *testing.T) {
+	violation := GateViolation{
+		Reason: "rule 2.1.2 failed 1 time(s)",
+		Findings: []RegulaRuleResult{
+			{RuleID: "2.1.2", RuleName: "aws_ec2_ami_encryption", RuleSeverity: "High", ResourceID: "aws_ami.a", Filepath: "main.tf"},
+		},
+	}
+	got := violation.String()
+	if !strings.Contains(got, "[High] 2.1.2 aws_ec2_ami_encryption: aws_ami.a (main.tf)") {
+		t.Errorf("GateViolation.String() = %q", got)
+	}
+}
@@ -1,38 +1,386 @@
 package resources
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"path/filepath"
-	"strconv"
 	"strings"
 	"terraform-provider-starchitect/resources/utils"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // IACPACResource defines the resource implementation.
-type IACPACResource struct{}
+type IACPACResource struct {
+	config *ProviderConfig
+}
 
 // IACPACResourceModel describes the resource data model.
 type IACPACResourceModel struct {
-	IACPath    types.String `tfsdk:"iac_path"`
-	PACPath    types.String `tfsdk:"pac_path"`
-	PACVersion types.String `tfsdk:"pac_version"`
-	LogPath    types.String `tfsdk:"log_path"`
-	ScanResult types.String `tfsdk:"scan_result"`
-	Score      types.String `tfsdk:"score"`
-	Threshold  types.String `tfsdk:"threshold"`
+	IACPath         types.String  `tfsdk:"iac_path"`
+	PlanJSONPath    types.String  `tfsdk:"plan_json_path"`
+	PACPath         types.String  `tfsdk:"pac_path"`
+	PACVersion      types.String  `tfsdk:"pac_version"`
+	PACRef          types.String  `tfsdk:"pac_ref"`
+	PACRepository   types.String  `tfsdk:"pac_repository"`
+	PACSubdirectory types.String  `tfsdk:"pac_subdirectory"`
+	TaxonomyFile    types.String  `tfsdk:"taxonomy_file"`
+	LogPath         types.String  `tfsdk:"log_path"`
+	ReportFormats   types.List    `tfsdk:"report_formats"`
+	Engine          types.String  `tfsdk:"engine"`
+	ScanResult      types.String  `tfsdk:"scan_result"`
+	Score           types.String  `tfsdk:"score"`
+	ScorePercent    types.Float64 `tfsdk:"score_percent"`
+	PassedCount     types.Int64   `tfsdk:"passed_count"`
+	FailedCount     types.Int64   `tfsdk:"failed_count"`
+	WaivedCount     types.Int64   `tfsdk:"waived_count"`
+	TotalCount      types.Int64   `tfsdk:"total_count"`
+	Threshold       types.Float64 `tfsdk:"threshold"`
+	Findings        types.List    `tfsdk:"findings"`
+	Coverage        types.Object  `tfsdk:"coverage"`
+	Compliance      types.Map     `tfsdk:"compliance"`
+	MinCoverage     types.Float64 `tfsdk:"min_coverage"`
+
+	IncludeRules      types.List   `tfsdk:"include_rules"`
+	ExcludeRules      types.List   `tfsdk:"exclude_rules"`
+	IncludeFrameworks types.List   `tfsdk:"include_frameworks"`
+	MinSeverity       types.String `tfsdk:"min_severity"`
+	Families          types.List   `tfsdk:"families"`
+
+	ScoringMode       types.String `tfsdk:"scoring_mode"`
+	SeverityWeights   types.Map    `tfsdk:"severity_weights"`
+	SeverityBreakdown types.Map    `tfsdk:"severity_breakdown"`
+
+	FailOn  *FailOnModel  `tfsdk:"fail_on"`
+	Waivers []WaiverModel `tfsdk:"waiver"`
+	PACAuth *PACAuthModel `tfsdk:"pac_auth"`
+
+	PACVerification *PACVerificationModel `tfsdk:"pac_verification"`
+}
+
+// PACAuthModel describes the pac_auth block.
+type PACAuthModel struct {
+	Username          types.String `tfsdk:"username"`
+	Token             types.String `tfsdk:"token"`
+	SSHPrivateKey     types.String `tfsdk:"ssh_private_key"`
+	SSHPrivateKeyFile types.String `tfsdk:"ssh_private_key_file"`
+	SSHKeyPassphrase  types.String `tfsdk:"ssh_key_passphrase"`
+}
+
+// auth converts the block into the credentials used to clone the PAC repository.
+func (m *PACAuthModel) auth() utils.PACAuth {
+	if m == nil {
+		return utils.PACAuth{}
+	}
+	return utils.PACAuth{
+		Username:          m.Username.ValueString(),
+		Token:             m.Token.ValueString(),
+		SSHPrivateKey:     m.SSHPrivateKey.ValueString(),
+		SSHPrivateKeyFile: m.SSHPrivateKeyFile.ValueString(),
+		SSHKeyPassphrase:  m.SSHKeyPassphrase.ValueString(),
+	}
+}
+
+// PACVerificationModel describes the pac_verification block.
+type PACVerificationModel struct {
+	Commit        types.String `tfsdk:"commit"`
+	ChecksumFile  types.String `tfsdk:"checksum_file"`
+	PublicKey     types.String `tfsdk:"public_key"`
+	SignatureFile types.String `tfsdk:"signature_file"`
+}
+
+// verification converts the block into the checks of the fetched policies.
+func (m *PACVerificationModel) verification() utils.PACVerification {
+	if m == nil {
+		return utils.PACVerification{}
+	}
+	return utils.PACVerification{
+		Commit:        m.Commit.ValueString(),
+		ChecksumFile:  m.ChecksumFile.ValueString(),
+		PublicKey:     m.PublicKey.ValueString(),
+		SignatureFile: m.SignatureFile.ValueString(),
+	}
+}
+
+// WaiverModel describes a waiver block.
+type WaiverModel struct {
+	RuleID     types.String `tfsdk:"rule_id"`
+	ResourceID types.String `tfsdk:"resource_id"`
+	Reason     types.String `tfsdk:"reason"`
+	Expires    types.String `tfsdk:"expires"`
+	Owner      types.String `tfsdk:"owner"`
+}
+
+// FailOnModel describes the fail_on block.
+type FailOnModel struct {
+	Critical      types.Int64 `tfsdk:"critical"`
+	High          types.Int64 `tfsdk:"high"`
+	Medium        types.Int64 `tfsdk:"medium"`
+	Low           types.Int64 `tfsdk:"low"`
+	Informational types.Int64 `tfsdk:"informational"`
+	RuleIDs       types.List  `tfsdk:"rule_ids"`
+}
+
+// gate converts the block into the gate evaluated against the findings.
+func (m FailOnModel) gate(ctx context.Context) (FailOnGate, diag.Diagnostics) {
+	gate := FailOnGate{MaxFailures: map[string]int64{}}
+	for severity, maxFailures := range map[string]types.Int64{
+		"Critical":      m.Critical,
+		"High":          m.High,
+		"Medium":        m.Medium,
+		"Low":           m.Low,
+		"Informational": m.Informational,
+	} {
+		if !maxFailures.IsNull() && !maxFailures.IsUnknown() {
+			gate.MaxFailures[severity] = maxFailures.ValueInt64()
+		}
+	}
+
+	var diags diag.Diagnostics
+	if !m.RuleIDs.IsNull() && !m.RuleIDs.IsUnknown() {
+		diags = m.RuleIDs.ElementsAs(ctx, &gate.RuleIDs, false)
+	}
+	return gate, diags
+}
+
+// SeverityBreakdownModel describes the per-severity entries of severity_breakdown.
+type SeverityBreakdownModel struct {
+	Passed int64   `tfsdk:"passed"`
+	Failed int64   `tfsdk:"failed"`
+	Waived int64   `tfsdk:"waived"`
+	Weight float64 `tfsdk:"weight"`
+}
+
+var severityBreakdownAttrTypes = map[string]attr.Type{
+	"passed": types.Int64Type,
+	"failed": types.Int64Type,
+	"waived": types.Int64Type,
+	"weight": types.Float64Type,
+}
+
+// scanOptions builds the scan options from the configured attributes,
+// falling back to the provider configuration for unset ones.
+func (m IACPACResourceModel) scanOptions(ctx context.Context, config *ProviderConfig) (ScanOptions, diag.Diagnostics) {
+	opts := ScanOptions{
+		IACPath:         m.IACPath.ValueString(),
+		PlanJSONPath:    m.PlanJSONPath.ValueString(),
+		PACPath:         m.PACPath.ValueString(),
+		PACVersion:      m.PACVersion.ValueString(),
+		PACRepository:   m.PACRepository.ValueString(),
+		PACSubdirectory: m.PACSubdirectory.ValueString(),
+		TaxonomyFile:    m.TaxonomyFile.ValueString(),
+		PACAuth:         m.PACAuth.auth(),
+		PACVerification: m.PACVerification.verification(),
+		LogPath:         m.LogPath.ValueString(),
+		Engine:          m.Engine.ValueString(),
+		Scoring: ScoringModel{
+			Mode: m.ScoringMode.ValueString(),
+		},
+	}
+	if m.PACRef.ValueString() != "" {
+		opts.PACVersion = m.PACRef.ValueString()
+	}
+	if !m.Threshold.IsNull() && !m.Threshold.IsUnknown() {
+		threshold := m.Threshold.ValueFloat64()
+		opts.Threshold = &threshold
+	}
+	if !m.MinCoverage.IsNull() && !m.MinCoverage.IsUnknown() {
+		minCoverage := m.MinCoverage.ValueFloat64()
+		opts.MinCoverage = &minCoverage
+	}
+	config.applyDefaults(&opts)
+
+	var diags diag.Diagnostics
+	if !m.SeverityWeights.IsNull() && !m.SeverityWeights.IsUnknown() {
+		weights := map[string]float64{}
+		diags = m.SeverityWeights.ElementsAs(ctx, &weights, false)
+		normalized, err := normalizeSeverityWeights(weights)
+		if err != nil {
+			diags.AddAttributeError(path.Root("severity_weights"), "Invalid severity weights", err.Error())
+		}
+		opts.Scoring.Weights = normalized
+	}
+	opts.RuleFilter.MinSeverity = m.MinSeverity.ValueString()
+	for _, list := range []struct {
+		value  types.List
+		target *[]string
+	}{
+		{m.ReportFormats, &opts.ReportFormats},
+		{m.IncludeRules, &opts.RuleFilter.IncludeRules},
+		{m.ExcludeRules, &opts.RuleFilter.ExcludeRules},
+		{m.IncludeFrameworks, &opts.RuleFilter.IncludeFrameworks},
+		{m.Families, &opts.RuleFilter.Families},
+	} {
+		if !list.value.IsNull() && !list.value.IsUnknown() {
+			diags.Append(list.value.ElementsAs(ctx, list.target, false)...)
+		}
+	}
+
+	for i, waiver := range m.Waivers {
+		w := Waiver{
+			RuleID:     waiver.RuleID.ValueString(),
+			ResourceID: waiver.ResourceID.ValueString(),
+			Reason:     waiver.Reason.ValueString(),
+			Owner:      waiver.Owner.ValueString(),
+		}
+		if err := validateWaiverResourceID(w.ResourceID); err != nil {
+			diags.AddAttributeError(
+				path.Root("waiver").AtListIndex(i).AtName("resource_id"),
+				"Invalid waiver resource_id",
+				err.Error(),
+			)
+			continue
+		}
+		if waiver.Expires.ValueString() != "" {
+			expires, err := parseWaiverExpiry(waiver.Expires.ValueString())
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("waiver").AtListIndex(i).AtName("expires"),
+					"Invalid waiver expiry",
+					err.Error(),
+				)
+				continue
+			}
+			w.Expires = expires
+		}
+		opts.Waivers = append(opts.Waivers, w)
+	}
+	return opts, diags
+}
+
+// checkResult evaluates the waivers, threshold, min_coverage and fail_on gate
+// against a scan result. Gate failures are reported as errors, waiver problems as warnings.
+func (m IACPACResourceModel) checkResult(ctx context.Context, opts ScanOptions, result ScanResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, waiver := range result.Waivers {
+		if waiver.Expired {
+			diags.AddWarning(
+				"Waiver Expired",
+				fmt.Sprintf("The waiver of rule %s for resources %q owned by %q expired on %s and no longer applies. Reason: %s",
+					waiver.RuleID, waiver.ResourceID, waiver.Owner, waiver.Expires.Format(time.RFC3339), waiver.Reason),
+			)
+		} else if waiver.Matched == 0 {
+			diags.AddWarning(
+				"Waiver Matches Nothing",
+				fmt.Sprintf("The waiver of rule %s for resources %q owned by %q does not match any failing finding and can be removed. Reason: %s",
+					waiver.RuleID, waiver.ResourceID, waiver.Owner, waiver.Reason),
+			)
+		}
+	}
+
+	// Check threshold if specified
+	if opts.Threshold != nil {
+		thresholdValue := *opts.Threshold
+		if !result.Score.Evaluated() {
+			diags.AddWarning(
+				"Security Score Not Evaluated",
+				fmt.Sprintf("No PASS or FAIL results were found, threshold (%.2f%%) was not evaluated", thresholdValue),
+			)
+		} else if result.Score.Percent < thresholdValue {
+			diags.AddError(
+				"Security Score Below Threshold",
+				fmt.Sprintf("Security score (%.2f%%) is below the required threshold (%.2f%%)", result.Score.Percent, thresholdValue),
+			)
+		}
+	}
+
+	// Check the share of resources evaluated by at least one rule if specified
+	if opts.MinCoverage != nil {
+		minCoverage := *opts.MinCoverage
+		if !result.Coverage.Evaluated() {
+			diags.AddWarning(
+				"Policy Coverage Not Evaluated",
+				fmt.Sprintf("No cloud resources were found, min_coverage (%.2f%%) was not evaluated", minCoverage),
+			)
+		} else if result.Coverage.Percent() < minCoverage {
+			diags.AddError(
+				"Policy Coverage Below Minimum",
+				fmt.Sprintf("Policy coverage (%.2f%%, %d of %d resources) is below the required min_coverage (%.2f%%):\n%s",
+					result.Coverage.Percent(), result.Coverage.Covered, result.Coverage.Resources, minCoverage, result.Coverage.gaps()),
+			)
+		}
+	}
+
+	// Check the per-severity and per-rule failure gate if specified
+	if m.FailOn != nil {
+		gate, gateDiags := m.FailOn.gate(ctx)
+		diags.Append(gateDiags...)
+		if gateDiags.HasError() {
+			return diags
+		}
+		for _, violation := range gate.Evaluate(result.RuleResults) {
+			diags.AddError(
+				"Failure Gate Tripped",
+				fmt.Sprintf("fail_on gate tripped, %s", violation),
+			)
+		}
+	}
+	return diags
+}
+
+// setScanResult copies the outcome of a scan into the computed attributes.
+func (m *IACPACResourceModel) setScanResult(ctx context.Context, result ScanResult) diag.Diagnostics {
+	m.ScanResult = types.StringValue(result.Summary)
+	m.Score = types.StringValue(result.Score.String())
+	m.ScorePercent = types.Float64Null()
+	if result.Score.Evaluated() {
+		m.ScorePercent = types.Float64Value(result.Score.Percent)
+	}
+	m.PassedCount = types.Int64Value(result.Score.Passed)
+	m.FailedCount = types.Int64Value(result.Score.Failed)
+	m.WaivedCount = types.Int64Value(result.Score.Waived)
+	m.TotalCount = types.Int64Value(result.Score.Total)
+	m.ScoringMode = types.StringValue(result.Score.Mode)
+
+	breakdown := map[string]SeverityBreakdownModel{}
+	for severity, bucket := range result.Score.Severities {
+		breakdown[severity] = SeverityBreakdownModel{
+			Passed: bucket.Passed,
+			Failed: bucket.Failed,
+			Waived: bucket.Waived,
+			Weight: bucket.Weight,
+		}
+	}
+	severityBreakdown, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: severityBreakdownAttrTypes}, breakdown)
+	m.SeverityBreakdown = severityBreakdown
+
+	findings, findingsDiags := findingsValue(ctx, result.RuleResults)
+	diags.Append(findingsDiags...)
+	m.Findings = findings
+
+	coverage, coverageDiags := coverageValue(ctx, result.Coverage)
+	diags.Append(coverageDiags...)
+	m.Coverage = coverage
+
+	compliance, complianceDiags := complianceValue(ctx, result.Compliance)
+	diags.Append(complianceDiags...)
+	m.Compliance = compliance
+	return diags
+}
+
+// setScanResultUnknown marks the computed scan results unknown until the scan runs.
+func (m *IACPACResourceModel) setScanResultUnknown() {
+	m.ScanResult = types.StringUnknown()
+	m.Score = types.StringUnknown()
+	m.ScorePercent = types.Float64Unknown()
+	m.PassedCount = types.Int64Unknown()
+	m.FailedCount = types.Int64Unknown()
+	m.WaivedCount = types.Int64Unknown()
+	m.TotalCount = types.Int64Unknown()
+	m.SeverityBreakdown = types.MapUnknown(types.ObjectType{AttrTypes: severityBreakdownAttrTypes})
+	m.Findings = types.ListUnknown(types.ObjectType{AttrTypes: findingAttrTypes})
+	m.Coverage = types.ObjectUnknown(coverageAttrTypes)
+	m.Compliance = types.MapUnknown(types.ObjectType{AttrTypes: complianceFrameworkAttrTypes})
 }
 
 type RegulaRuleResult struct {
@@ -52,12 +400,91 @@ type RegulaRuleResult struct {
 	RuleResult      string            `json:"rule_result"`
 	RuleSeverity    string            `json:"rule_severity"`
 	RuleSummary     string            `json:"rule_summary"`
+	// SourceLocation is where the resource is declared, starting with the resource block.
+	SourceLocation []RegulaSourceLocation `json:"source_location,omitempty"`
+
+	// Waiver is the waiver that marked the result as WAIVED, if any.
+	Waiver *Waiver `json:"-"`
+}
+
+// RegulaSourceLocation is a location in the IaC reported with a rule result.
+type RegulaSourceLocation struct {
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	// EndLine is the last line of the resource block. It is only reported by the native engine.
+	EndLine int `json:"end_line,omitempty"`
+}
+
+// location returns the file and the lines of the resource block, zero when unknown.
+func (r RegulaRuleResult) location() (string, int, int) {
+	if len(r.SourceLocation) == 0 {
+		return r.Filepath, 0, 0
+	}
+	location := r.SourceLocation[0]
+	endLine := location.EndLine
+	if endLine < location.Line {
+		endLine = location.Line
+	}
+	if location.Path == "" {
+		location.Path = r.Filepath
+	}
+	return location.Path, location.Line, endLine
 }
 
 type RegulaOutput struct {
 	RuleResults []RegulaRuleResult `json:"rule_results"`
 }
 
+// ScanOptions holds the inputs of a single scan.
+type ScanOptions struct {
+	IACPath string
+	// PlanJSONPath is the output of terraform show -json scanned instead of IACPath.
+	PlanJSONPath string
+	PACPath      string
+	// PACVersion is the branch, tag or commit SHA of the PAC repository.
+	PACVersion      string
+	PACRepository   string
+	PACSubdirectory string
+	// TaxonomyFile is merged over the taxonomy of the PAC to select the policies.
+	TaxonomyFile    string
+	PACAuth         utils.PACAuth
+	PACVerification utils.PACVerification
+	LogPath         string
+	// ReportFormats are the reports written to LogPath, DefaultReportFormats when nil.
+	ReportFormats []string
+	Engine        string
+	EnginePath    string
+	PACCache      utils.PACCacheOptions
+	// Offline resolves the PAC from the rule packs in RulePackPath only.
+	Offline      bool
+	RulePackPath string
+	Scoring      ScoringModel
+	Waivers      []Waiver
+	// Threshold is the minimum security score, nil when unset.
+	Threshold *float64
+	// MinCoverage is the minimum share of covered resources, nil when unset.
+	MinCoverage *float64
+	// RuleFilter selects the rules evaluated by the engine.
+	RuleFilter RuleFilter
+}
+
+// ScanResult holds everything produced by a single scan.
+type ScanResult struct {
+	// Summary is the formatted, human readable scan report.
+	Summary string
+	// Score is the pass/fail summary produced by calculateScore.
+	Score ScoreSummary
+	// RuleResults are the individual rule evaluations reported by regula.
+	RuleResults []RegulaRuleResult
+	// Waivers reports how every configured waiver was applied.
+	Waivers []WaiverStatus
+	// Coverage reports the resources no rule was evaluated against.
+	Coverage Coverage
+	// Compliance rolls the controls of the rule results up per framework.
+	Compliance []FrameworkCompliance
+}
+
 func NewIACPACResource() resource.Resource {
 	return &IACPACResource{}
 }
@@ -67,6 +494,11 @@ func NewIACPACResourceWithModifyPlan() resource.ResourceWithModifyPlan {
 }
 
 func (r *IACPACResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to scan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
 	var plan IACPACResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -74,61 +506,47 @@ func (r *IACPACResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		return
 	}
 
-	iacPath := plan.IACPath.ValueString()
-	pacPath := plan.PACPath.ValueString()
-	pacVersion := plan.PACVersion.ValueString()
-	threshold := plan.Threshold.ValueString()
-	logPath := plan.LogPath.ValueString()
-
-	scanResult, score := GetScanResult(iacPath, pacPath, pacVersion, logPath)
-	plan.ScanResult = types.StringValue(scanResult)
-	plan.Score = types.StringValue(score)
-
-	// Check threshold if specified
-	if threshold != "" {
-		thresholdValue, err := strconv.ParseFloat(threshold, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid threshold value",
-				fmt.Sprintf("Could not parse threshold value: %v", err),
-			)
-			return
-		}
+	// The scan and its gates run at apply time once the paths are known, the
+	// results of the prior scan no longer apply
+	if plan.IACPath.IsUnknown() || plan.PlanJSONPath.IsUnknown() || plan.PACPath.IsUnknown() {
+		plan.setScanResultUnknown()
+		diags = resp.Plan.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
 
-		// Extract score value
-		scoreStr := strings.TrimSpace(score)
-		parts := strings.Split(scoreStr, "Score: ")
-		if len(parts) != 2 {
-			resp.Diagnostics.AddError(
-				"Invalid score format",
-				fmt.Sprintf("Could not parse score from: %s", score),
-			)
-			return
-		}
+	opts, diags := plan.scanOptions(ctx, r.config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		scoreStr = strings.TrimSuffix(parts[1], " percent")
-		scoreValue, err := strconv.ParseFloat(scoreStr, 64)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid score value",
-				fmt.Sprintf("Could not parse score value: %v", err),
-			)
-			return
-		}
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(scanErrorDiagnostics(err)...)
+		return
+	}
+	diags = plan.setScanResult(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		if scoreValue < thresholdValue {
-			resp.Diagnostics.AddError(
-				"Security Score Below Threshold",
-				fmt.Sprintf("Security score (%.2f%%) is below the required threshold (%.2f%%)", scoreValue, thresholdValue),
-			)
-			return
-		}
+	resp.Diagnostics.Append(plan.checkResult(ctx, opts, result)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	diags = resp.Plan.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+func (r *IACPACResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	config, diags := providerConfig(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.config = config
+}
+
 func (r *IACPACResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_iac_pac"
 }
@@ -136,42 +554,9 @@ func (r *IACPACResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *IACPACResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resschema.Schema{
 		Description: "accepts IAC and PAC path to run policies",
-		Attributes: map[string]resschema.Attribute{
-			"iac_path": resschema.StringAttribute{
-				Description: "IAC path",
-				Required:    true,
-			},
-			"pac_path": resschema.StringAttribute{
-				Description: "PAC path",
-				Optional:    true,
-			},
-			"pac_version": resschema.StringAttribute{
-				Description: "default PAC version",
-				Optional:    true,
-			},
-			"log_path": resschema.StringAttribute{
-				Description: "Path to store log files",
-				Optional:    true,
-			},
-			"threshold": resschema.StringAttribute{
-				Description: "Minimum required security score (0-100)",
-				Optional:    true,
-			},
-			"scan_result": resschema.StringAttribute{
-				Description: "Generated scan result",
-				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"score": resschema.StringAttribute{
-				Description: "Generated score. evaluated from scan result",
-				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-		},
+		Version:     1,
+		Attributes:  resourceAttributes(scanAttributes()),
+		Blocks:      scanBlocks(),
 	}
 }
 
@@ -183,14 +568,28 @@ func (r *IACPACResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	iacPath := plan.IACPath.ValueString()
-	pacPath := plan.PACPath.ValueString()
-	pacVersion := plan.PACVersion.ValueString()
-	logPath := plan.LogPath.ValueString()
+	opts, diags := plan.scanOptions(ctx, r.config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(scanErrorDiagnostics(err)...)
+		return
+	}
+	diags = plan.setScanResult(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	scanResult, score := GetScanResult(iacPath, pacPath, pacVersion, logPath)
-	plan.ScanResult = types.StringValue(scanResult)
-	plan.Score = types.StringValue(score)
+	// The gates are checked again, the plan skips them when the paths were unknown
+	resp.Diagnostics.Append(plan.checkResult(ctx, opts, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -204,14 +603,22 @@ func (r *IACPACResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	iacPath := state.IACPath.ValueString()
-	pacPath := state.PACPath.ValueString()
-	pacVersion := state.PACVersion.ValueString()
-	logPath := state.LogPath.ValueString()
+	opts, diags := state.scanOptions(ctx, r.config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	scanResult, score := GetScanResult(iacPath, pacPath, pacVersion, logPath)
-	state.ScanResult = types.StringValue(scanResult)
-	state.Score = types.StringValue(score)
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(scanErrorDiagnostics(err)...)
+		return
+	}
+	diags = state.setScanResult(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -225,14 +632,28 @@ func (r *IACPACResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	iacPath := plan.IACPath.ValueString()
-	pacPath := plan.PACPath.ValueString()
-	pacVersion := plan.PACVersion.ValueString()
-	logPath := plan.LogPath.ValueString()
+	opts, diags := plan.scanOptions(ctx, r.config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	scanResult, score := GetScanResult(iacPath, pacPath, pacVersion, logPath)
-	plan.ScanResult = types.StringValue(scanResult)
-	plan.Score = types.StringValue(score)
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(scanErrorDiagnostics(err)...)
+		return
+	}
+	diags = plan.setScanResult(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The gates are checked again, the plan skips them when the paths were unknown
+	resp.Diagnostics.Append(plan.checkResult(ctx, opts, result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -247,20 +668,25 @@ func (r *IACPACResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
-func formatRegulaOutput(regulaOutput RegulaOutput) string {
+func formatRegulaOutput(regulaOutput RegulaOutput, filter RuleFilter) string {
 	var formatted strings.Builder
 
 	// Add timestamp
 	formatted.WriteString(fmt.Sprintf("Scan Time: %s\n", time.Now().Format(time.RFC3339)))
+	if !filter.IsEmpty() {
+		formatted.WriteString(fmt.Sprintf("Rule Filters: %s\n", filter))
+	}
 	formatted.WriteString("====================\n\n")
 
 	// Calculate summary
-	var passCount, failCount int
+	var passCount, failCount, waivedCount int
 	for _, rule := range regulaOutput.RuleResults {
 		if rule.RuleResult == "PASS" {
 			passCount++
 		} else if rule.RuleResult == "FAIL" {
 			failCount++
+		} else if rule.RuleResult == "WAIVED" {
+			waivedCount++
 		}
 	}
 
@@ -268,148 +694,148 @@ func formatRegulaOutput(regulaOutput RegulaOutput) string {
 	formatted.WriteString("Summary:\n")
 	formatted.WriteString(fmt.Sprintf("PASSED: %d\n", passCount))
 	formatted.WriteString(fmt.Sprintf("FAILED: %d\n", failCount))
+	if waivedCount > 0 {
+		formatted.WriteString(fmt.Sprintf("WAIVED: %d\n", waivedCount))
+	}
+	writeCompliance(&formatted, calculateCompliance(regulaOutput.RuleResults))
+
 	formatted.WriteString("\nDetailed Results:\n")
 	formatted.WriteString("----------------\n")
 
 	for _, rule := range regulaOutput.RuleResults {
-		formatted.WriteString(fmt.Sprintf("\nRule ID: %s\n", rule.RuleID))
-		formatted.WriteString(fmt.Sprintf("Name: %s\n", rule.RuleName))
-		formatted.WriteString(fmt.Sprintf("Result: %s\n", rule.RuleResult))
-		formatted.WriteString(fmt.Sprintf("Severity: %s\n", rule.RuleSeverity))
-		formatted.WriteString(fmt.Sprintf("Summary: %s\n", rule.RuleSummary))
-		formatted.WriteString(fmt.Sprintf("Description: %s\n", rule.RuleDescription))
-
-		if rule.ResourceType != "" {
-			formatted.WriteString(fmt.Sprintf("Resource Type: %s\n", rule.ResourceType))
-		}
-		if rule.ResourceID != "" {
-			formatted.WriteString(fmt.Sprintf("Resource ID: %s\n", rule.ResourceID))
-		}
-		if rule.RuleMessage != "" {
-			formatted.WriteString(fmt.Sprintf("Message: %s\n", rule.RuleMessage))
+		if rule.RuleResult == "WAIVED" {
+			continue
 		}
+		writeRuleResult(&formatted, rule)
+	}
 
-		if len(rule.Controls) > 0 {
-			formatted.WriteString("Controls:\n")
-			for _, control := range rule.Controls {
-				formatted.WriteString(fmt.Sprintf("  - %s\n", control))
-			}
-		}
+	// Waived results are listed separately with the accepted risk
+	if waivedCount > 0 {
+		formatted.WriteString("\nWaived Results:\n")
+		formatted.WriteString("--------------\n")
 
-		if len(rule.Families) > 0 {
-			formatted.WriteString("Families:\n")
-			for _, family := range rule.Families {
-				formatted.WriteString(fmt.Sprintf("  - %s\n", family))
+		for _, rule := range regulaOutput.RuleResults {
+			if rule.RuleResult == "WAIVED" {
+				writeRuleResult(&formatted, rule)
 			}
 		}
-
-		formatted.WriteString("---\n")
 	}
 
 	return formatted.String()
 }
 
-func writeToLogFiles(rawOutput string, formattedOutput string, logPath string) error {
-	timestamp := time.Now().Format("20060102_150405")
+func writeRuleResult(formatted *strings.Builder, rule RegulaRuleResult) {
+	formatted.WriteString(fmt.Sprintf("\nRule ID: %s\n", rule.RuleID))
+	formatted.WriteString(fmt.Sprintf("Name: %s\n", rule.RuleName))
+	formatted.WriteString(fmt.Sprintf("Result: %s\n", rule.RuleResult))
+	formatted.WriteString(fmt.Sprintf("Severity: %s\n", rule.RuleSeverity))
+	formatted.WriteString(fmt.Sprintf("Summary: %s\n", rule.RuleSummary))
+	formatted.WriteString(fmt.Sprintf("Description: %s\n", rule.RuleDescription))
 
-	// Create log directory if it doesn't exist
-	if logPath != "" {
-		if err := os.MkdirAll(logPath, 0755); err != nil {
-			return fmt.Errorf("failed to create log directory: %v", err)
-		}
+	if rule.ResourceType != "" {
+		formatted.WriteString(fmt.Sprintf("Resource Type: %s\n", rule.ResourceType))
 	}
-
-	// Write raw output to JSON file
-	rawFileName := fmt.Sprintf("%s_starchitect_raw.json", timestamp)
-	if logPath != "" {
-		rawFileName = filepath.Join(logPath, rawFileName)
+	if rule.ResourceID != "" {
+		formatted.WriteString(fmt.Sprintf("Resource ID: %s\n", rule.ResourceID))
 	}
-	if err := os.WriteFile(rawFileName, []byte(rawOutput), 0644); err != nil {
-		return fmt.Errorf("failed to write raw output: %v", err)
+	if rule.RuleMessage != "" {
+		formatted.WriteString(fmt.Sprintf("Message: %s\n", rule.RuleMessage))
 	}
 
-	// Write formatted summary to log file
-	summaryFileName := fmt.Sprintf("%s_starchitect_summary.log", timestamp)
-	if logPath != "" {
-		summaryFileName = filepath.Join(logPath, summaryFileName)
-	}
-	if err := os.WriteFile(summaryFileName, []byte(formattedOutput), 0644); err != nil {
-		return fmt.Errorf("failed to write summary: %v", err)
+	if len(rule.Controls) > 0 {
+		formatted.WriteString("Controls:\n")
+		for _, control := range rule.Controls {
+			formatted.WriteString(fmt.Sprintf("  - %s\n", control))
+		}
 	}
 
-	return nil
-}
-
-func calculateScore(regulaOutput RegulaOutput) string {
-	var passCount, failCount int
-	for _, rule := range regulaOutput.RuleResults {
-		if rule.RuleResult == "PASS" {
-			passCount++
-		} else if rule.RuleResult == "FAIL" {
-			failCount++
+	if len(rule.Families) > 0 {
+		formatted.WriteString("Families:\n")
+		for _, family := range rule.Families {
+			formatted.WriteString(fmt.Sprintf("  - %s\n", family))
 		}
 	}
 
-	total := passCount + failCount
-	if total == 0 {
-		return "no PASS or FAIL results found"
+	if rule.Waiver != nil {
+		formatted.WriteString(fmt.Sprintf("Waiver Reason: %s\n", rule.Waiver.Reason))
+		if rule.Waiver.Owner != "" {
+			formatted.WriteString(fmt.Sprintf("Waiver Owner: %s\n", rule.Waiver.Owner))
+		}
+		if !rule.Waiver.Expires.IsZero() {
+			formatted.WriteString(fmt.Sprintf("Waiver Expires: %s\n", rule.Waiver.Expires.Format(time.RFC3339)))
+		}
 	}
 
-	score := (float64(passCount) / float64(total)) * 100
-	return fmt.Sprintf("PASSED: %d FAILED: %d Score: %.2f percent", passCount, failCount, score)
+	formatted.WriteString("---\n")
 }
 
-func GetScanResult(iacPath, pacPath, pacVersion, logPath string) (string, string) {
+// markPlanError attributes IaC parse errors to plan_json_path when a plan is
+// scanned through it and to iac_path otherwise, even for plan files in iac_path.
+func markPlanError(err error, opts ScanOptions) error {
+	var parseErr *utils.IaCParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Plan = opts.PlanJSONPath != ""
+	}
+	return err
+}
 
-	var err error
-	if pacPath == "" {
-		pacPath, err = utils.GetDefaultPAC(iacPath, pacVersion)
-		if err != nil {
-			return err.Error(), ""
+// GetScanResult scans the IaC against the policies. Failures are returned as
+// typed errors, see scanErrorDiagnostics.
+func GetScanResult(ctx context.Context, opts ScanOptions) (ScanResult, error) {
+	input := ScanInput{Path: opts.IACPath, Type: InputTypeTerraform}
+	if utils.IsTerraformPlan(opts.IACPath) {
+		input.Type = InputTypeTerraformPlan
+	}
+	if opts.PlanJSONPath != "" {
+		input = ScanInput{Path: opts.PlanJSONPath, Type: InputTypeTerraformPlan}
+		if info, err := os.Stat(input.Path); err == nil && info.IsDir() {
+			return ScanResult{}, &utils.IaCParseError{
+				Problems: []utils.SourceProblem{{File: input.Path, Message: "plan_json_path must be a file written by terraform show -json"}},
+				Plan:     true,
+			}
 		}
 	}
+	pacPath := opts.PACPath
 
-	var stderr bytes.Buffer
-	tempDir, err := os.MkdirTemp("", "regula-scan")
+	engine, err := newScanEngine(opts.Engine, opts.EnginePath, opts.RuleFilter)
 	if err != nil {
-		return fmt.Sprintf("Error creating temporary directory: %v\n", err), ""
+		return ScanResult{}, err
 	}
-	defer os.RemoveAll(tempDir)
 
-	// Create the output file path in the temporary directory
-	outputFile := filepath.Join(tempDir, "results.json")
-
-	cmd := exec.Command(
-		"regula",
-		"run",
-		"-i", pacPath,
-		iacPath,
-		"-n",
-		"-f", "json",
-	)
-
-	// Redirect the output to the temporary file
-	output, err := os.Create(outputFile)
+	// The IaC is loaded once for the policy selection, the native engine and
+	// the coverage. regula parses it itself, with policies at pac_path only
+	// the coverage is lost when the native loader fails on it
+	input.Resources, err = utils.LoadIaC(input.Path)
 	if err != nil {
-		return fmt.Sprintf("Error creating output file: %v\n", err), ""
+		if opts.Engine != EngineRegula || pacPath == "" {
+			return ScanResult{}, markPlanError(err, opts)
+		}
+		log.Printf("Warning: Failed to load the resources for the coverage: %v", err)
 	}
-	defer output.Close()
 
-	cmd.Stdout = output
-	cmd.Stderr = &stderr
-
-	err = cmd.Run()
-	if err != nil {
-		if bytes.Contains(stderr.Bytes(), []byte("rego_type_error")) {
-			return fmt.Sprintf("Error: rego_type_error encountered. %v", string(stderr.String())), ""
+	pacCoverage := utils.PACCoverage{}
+	if pacPath == "" {
+		var cleanup func()
+		pacPath, pacCoverage, cleanup, err = utils.GetDefaultPAC(input.Resources, utils.PACSource{
+			Repository:   opts.PACRepository,
+			Ref:          opts.PACVersion,
+			Subdirectory: opts.PACSubdirectory,
+			TaxonomyFile: opts.TaxonomyFile,
+			Auth:         opts.PACAuth,
+			Cache:        opts.PACCache,
+			Offline:      opts.Offline,
+			RulePackPath: opts.RulePackPath,
+			Verification: opts.PACVerification,
+		})
+		if err != nil {
+			return ScanResult{}, markPlanError(err, opts)
 		}
-		err = nil
+		defer cleanup()
 	}
 
-	// Read the raw output
-	content, err := os.ReadFile(outputFile)
+	content, err := engine.Run(ctx, input, pacPath)
 	if err != nil {
-		return fmt.Sprintf("Error reading output file: %s %v\n", outputFile, err), ""
+		return ScanResult{}, markPlanError(err, opts)
 	}
 
 	rawOutput := string(content)
@@ -417,19 +843,33 @@ func GetScanResult(iacPath, pacPath, pacVersion, logPath string) (string, string
 	// Parse the JSON content
 	var regulaOutput RegulaOutput
 	if err := json.Unmarshal(content, &regulaOutput); err != nil {
-		return fmt.Sprintf("Error parsing JSON output: %v\n", err), ""
+		return ScanResult{}, fmt.Errorf("failed to parse scan output: %v", err)
 	}
 
+	// Mark waived findings before they are scored and reported
+	waivers := applyWaivers(&regulaOutput, opts.Waivers, time.Now())
+
 	// Calculate score
-	score := calculateScore(regulaOutput)
+	score := calculateScore(regulaOutput, opts.Scoring)
+
+	// Find the resources no rule was evaluated against
+	coverage := calculateCoverage(input.Resources, regulaOutput.RuleResults, pacCoverage, opts.RuleFilter)
 
 	// Format the summary output
-	formattedOutput := formatRegulaOutput(regulaOutput)
+	formattedOutput := formatRegulaOutput(regulaOutput, opts.RuleFilter)
 
-	// Write both outputs to separate files
-	if err := writeToLogFiles(rawOutput, formattedOutput, logPath); err != nil {
+	// Write the selected reports to separate files
+	report := scanReport{Raw: rawOutput, Summary: formattedOutput, Output: regulaOutput, Score: score, Filter: opts.RuleFilter}
+	if err := writeReports(report, opts.ReportFormats, opts.LogPath); err != nil {
 		log.Printf("Warning: Failed to write to log files: %v", err)
 	}
 
-	return formattedOutput, score
+	return ScanResult{
+		Summary:     formattedOutput,
+		Score:       score,
+		RuleResults: regulaOutput.RuleResults,
+		Waivers:     waivers,
+		Coverage:    coverage,
+		Compliance:  calculateCompliance(regulaOutput.RuleResults),
+	}, nil
 }
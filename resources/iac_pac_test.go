@@ -1,7 +1,13 @@
 package resources
 
 import (
+	"context"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestGetScanResult(t *testing.T) {
@@ -21,6 +27,14 @@ func TestGetScanResult(t *testing.T) {
 			logPath:    "test_logs",
 			wantErr:    false,
 		},
+		{
+			name:       "IAC file path",
+			iacPath:    "../testdata/valid_iac/main.tf",
+			pacPath:    "../testdata/valid_pac",
+			pacVersion: "",
+			logPath:    "test_logs",
+			wantErr:    false,
+		},
 		{
 			name:       "Invalid IAC path",
 			iacPath:    "../testdata/invalid_path",
@@ -33,11 +47,147 @@ func TestGetScanResult(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, score := GetScanResult(tt.iacPath, tt.pacPath, tt.pacVersion, tt.logPath)
-			if (result == "" || score == "") != tt.wantErr {
-				t.Errorf("GetScanResult() error = %v, wantErr %v", result, tt.wantErr)
+			result, err := GetScanResult(context.Background(), ScanOptions{
+				IACPath:    tt.iacPath,
+				PACPath:    tt.pacPath,
+				PACVersion: tt.pacVersion,
+				LogPath:    tt.logPath,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetScanResult() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if err == nil && (result.Summary == "" || result.RuleResults == nil) {
+				t.Errorf("GetScanResult() returned an empty result")
+			}
 		})
 	}
 }
+
+// testResourceModel returns a resource configuration scanning iacPath with
+// the test policies, unset attributes are null.
+func testResourceModel(t *testing.T, iacPath types.String) IACPACResourceModel {
+	return IACPACResourceModel{
+		IACPath:           iacPath,
+		PlanJSONPath:      types.StringNull(),
+		PACPath:           types.StringValue("../testdata/valid_pac"),
+		LogPath:           types.StringValue(t.TempDir()),
+		ReportFormats:     types.ListNull(types.StringType),
+		Engine:            types.StringValue(EngineNative),
+		Findings:          types.ListNull(types.ObjectType{AttrTypes: findingAttrTypes}),
+		Coverage:          types.ObjectNull(coverageAttrTypes),
+		Compliance:        types.MapNull(types.ObjectType{AttrTypes: complianceFrameworkAttrTypes}),
+		IncludeRules:      types.ListNull(types.StringType),
+		ExcludeRules:      types.ListNull(types.StringType),
+		IncludeFrameworks: types.ListNull(types.StringType),
+		Families:          types.ListNull(types.StringType),
+		ScoringMode:       types.StringValue(ScoringModeFlat),
+		SeverityWeights:   types.MapNull(types.Float64Type),
+		SeverityBreakdown: types.MapNull(types.ObjectType{AttrTypes: severityBreakdownAttrTypes}),
+	}
+}
+
+// testResourceSchema returns the schema of the iac_pac resource.
+func testResourceSchema(t *testing.T) resource.SchemaResponse {
+	schemaResp := resource.SchemaResponse{}
+	(&IACPACResource{}).Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+	return schemaResp
+}
+
+func TestIACPACResourceCreateGates(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := testResourceSchema(t)
+	nullState := func() tfsdk.State {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	}
+
+	// Paths known only at apply time skip the scan in the plan, the gates
+	// must still fail the apply
+	model := testResourceModel(t, types.StringValue("../testdata/valid_iac"))
+	model.Threshold = types.Float64Value(100)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+	resp := &resource.CreateResponse{State: nullState()}
+	(&IACPACResource{}).Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Security Score Below Threshold" {
+		t.Fatalf("Create() diagnostics = %v, want the threshold error", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("Create() stored the state of a scan failing its gates")
+	}
+
+	model.Threshold = types.Float64Value(0)
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+	resp = &resource.CreateResponse{State: nullState()}
+	(&IACPACResource{}).Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Errorf("Create() diagnostics = %v, want the scan stored", resp.Diagnostics)
+	}
+}
+
+func TestIACPACResourceUpdateUnknownPath(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := testResourceSchema(t)
+	terraformType := schemaResp.Schema.Type().TerraformType(ctx)
+	r := &IACPACResource{}
+
+	model := testResourceModel(t, types.StringValue("../testdata/valid_iac"))
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(terraformType, nil)}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(terraformType, nil)}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create() diagnostics = %v", createResp.Diagnostics)
+	}
+
+	// The framework plans the prior results through UseStateForUnknown before
+	// ModifyPlan runs, the path comes from a resource not created yet
+	var planned IACPACResourceModel
+	if diags := createResp.State.Get(ctx, &planned); diags.HasError() {
+		t.Fatalf("State.Get() diagnostics = %v", diags)
+	}
+	planned.IACPath = types.StringUnknown()
+	if diags := plan.Set(ctx, &planned); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+	modifyResp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: createResp.State}, modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan() diagnostics = %v", modifyResp.Diagnostics)
+	}
+	var got IACPACResourceModel
+	if diags := modifyResp.Plan.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("Plan.Get() diagnostics = %v", diags)
+	}
+	if !got.Score.IsUnknown() || !got.TotalCount.IsUnknown() || !got.Findings.IsUnknown() || !got.Coverage.IsUnknown() || !got.Compliance.IsUnknown() {
+		t.Errorf("ModifyPlan() planned score %v, total_count %v, findings known %v, want the scan results unknown",
+			got.Score, got.TotalCount, !got.Findings.IsUnknown())
+	}
+
+	// Once the path is known the update stores the new scan
+	got.IACPath = types.StringValue("../testdata/valid_iac/main.tf")
+	if diags := plan.Set(ctx, &got); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update() diagnostics = %v", updateResp.Diagnostics)
+	}
+	var updated IACPACResourceModel
+	if diags := updateResp.State.Get(ctx, &updated); diags.HasError() {
+		t.Fatalf("State.Get() diagnostics = %v", diags)
+	}
+	if updated.TotalCount.IsUnknown() || updated.Score.IsUnknown() {
+		t.Errorf("Update() stored unknown scan results")
+	}
+}
@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// iacPACResourceModelV0 describes the resource data model of schema version 0,
+// where threshold and score were only available as strings.
+type iacPACResourceModelV0 struct {
+	IACPath    types.String `tfsdk:"iac_path"`
+	PACPath    types.String `tfsdk:"pac_path"`
+	PACVersion types.String `tfsdk:"pac_version"`
+	LogPath    types.String `tfsdk:"log_path"`
+	ScanResult types.String `tfsdk:"scan_result"`
+	Score      types.String `tfsdk:"score"`
+	Threshold  types.String `tfsdk:"threshold"`
+}
+
+func (r *IACPACResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &resschema.Schema{
+				Attributes: map[string]resschema.Attribute{
+					"iac_path": resschema.StringAttribute{
+						Required: true,
+					},
+					"pac_path": resschema.StringAttribute{
+						Optional: true,
+					},
+					"pac_version": resschema.StringAttribute{
+						Optional: true,
+					},
+					"log_path": resschema.StringAttribute{
+						Optional: true,
+					},
+					"threshold": resschema.StringAttribute{
+						Optional: true,
+					},
+					"scan_result": resschema.StringAttribute{
+						Computed: true,
+					},
+					"score": resschema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			StateUpgrader: upgradeIACPACStateV0,
+		},
+	}
+}
+
+func upgradeIACPACStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior iacPACResourceModelV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	threshold := types.Float64Null()
+	if prior.Threshold.ValueString() != "" {
+		thresholdValue, err := strconv.ParseFloat(prior.Threshold.ValueString(), 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid threshold value",
+				fmt.Sprintf("Could not parse threshold value from prior state: %v", err),
+			)
+			return
+		}
+		threshold = types.Float64Value(thresholdValue)
+	}
+
+	// The numeric results and findings are populated again by the next refresh.
+	upgraded := IACPACResourceModel{
+		IACPath:         prior.IACPath,
+		PlanJSONPath:    types.StringNull(),
+		PACPath:         prior.PACPath,
+		PACVersion:      prior.PACVersion,
+		PACRef:          types.StringNull(),
+		PACRepository:   types.StringNull(),
+		PACSubdirectory: types.StringNull(),
+		TaxonomyFile:    types.StringNull(),
+		LogPath:         prior.LogPath,
+		ReportFormats:   types.ListNull(types.StringType),
+		Engine:          types.StringValue(EngineNative),
+		ScanResult:      prior.ScanResult,
+		Score:           prior.Score,
+		ScorePercent:    types.Float64Null(),
+		PassedCount:     types.Int64Null(),
+		FailedCount:     types.Int64Null(),
+		WaivedCount:     types.Int64Null(),
+		TotalCount:      types.Int64Null(),
+		Threshold:       threshold,
+		Findings:        types.ListNull(types.ObjectType{AttrTypes: findingAttrTypes}),
+		Coverage:        types.ObjectNull(coverageAttrTypes),
+		Compliance:      types.MapNull(types.ObjectType{AttrTypes: complianceFrameworkAttrTypes}),
+		MinCoverage:     types.Float64Null(),
+
+		IncludeRules:      types.ListNull(types.StringType),
+		ExcludeRules:      types.ListNull(types.StringType),
+		IncludeFrameworks: types.ListNull(types.StringType),
+		MinSeverity:       types.StringNull(),
+		Families:          types.ListNull(types.StringType),
+
+		ScoringMode:       types.StringValue(ScoringModeFlat),
+		SeverityWeights:   types.MapNull(types.Float64Type),
+		SeverityBreakdown: types.MapNull(types.ObjectType{AttrTypes: severityBreakdownAttrTypes}),
+		Waivers:           []WaiverModel{},
+	}
+
+	diags = resp.State.Set(ctx, upgraded)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"fmt"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ProviderConfig holds the settings of the provider block inherited by every
+// resource and data source. Values set on a resource take precedence.
+type ProviderConfig struct {
+	PACVersion      string
+	PACRepository   string
+	PACSubdirectory string
+	PACAuth         utils.PACAuth
+	PACVerification utils.PACVerification
+	LogPath         string
+	EnginePath      string
+	PACCache        utils.PACCacheOptions
+	Offline         bool
+	RulePackPath    string
+	TaxonomyFile    string
+	// Threshold is the default minimum security score, nil when unset.
+	Threshold *float64
+}
+
+// applyDefaults fills the options left unset by the resource.
+func (c *ProviderConfig) applyDefaults(opts *ScanOptions) {
+	if c == nil {
+		return
+	}
+	if opts.PACVersion == "" {
+		opts.PACVersion = c.PACVersion
+	}
+	if opts.PACRepository == "" {
+		opts.PACRepository = c.PACRepository
+	}
+	if opts.PACSubdirectory == "" {
+		opts.PACSubdirectory = c.PACSubdirectory
+	}
+	if opts.PACAuth == (utils.PACAuth{}) {
+		opts.PACAuth = c.PACAuth
+	}
+	if opts.PACVerification == (utils.PACVerification{}) {
+		opts.PACVerification = c.PACVerification
+	}
+	if opts.LogPath == "" {
+		opts.LogPath = c.LogPath
+	}
+	if opts.EnginePath == "" {
+		opts.EnginePath = c.EnginePath
+	}
+	if opts.PACCache == (utils.PACCacheOptions{}) {
+		opts.PACCache = c.PACCache
+	}
+	opts.Offline = opts.Offline || c.Offline
+	if opts.RulePackPath == "" {
+		opts.RulePackPath = c.RulePackPath
+	}
+	if opts.TaxonomyFile == "" {
+		opts.TaxonomyFile = c.TaxonomyFile
+	}
+	if opts.Threshold == nil {
+		opts.Threshold = c.Threshold
+	}
+}
+
+// providerConfig converts the provider data passed to Configure.
+func providerConfig(providerData any) (*ProviderConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if providerData == nil {
+		return nil, diags
+	}
+	config, ok := providerData.(*ProviderConfig)
+	if !ok {
+		diags.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *resources.ProviderConfig, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+	}
+	return config, diags
+}
@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"terraform-provider-starchitect/resources/utils"
+	"testing"
+	"time"
+)
+
+func TestProviderConfigApplyDefaults(t *testing.T) {
+	providerThreshold := 80.0
+	config := &ProviderConfig{
+		PACVersion:    "release",
+		PACRepository: "https://example.com/rules.git",
+		LogPath:       "logs",
+		EnginePath:    "/usr/local/bin/regula",
+		PACCache:      utils.PACCacheOptions{Dir: "cache", TTL: time.Hour},
+		Threshold:     &providerThreshold,
+	}
+
+	resourceThreshold := 50.0
+	opts := ScanOptions{PACVersion: "main", Threshold: &resourceThreshold}
+	config.applyDefaults(&opts)
+
+	if opts.PACVersion != "main" || *opts.Threshold != 50 {
+		t.Errorf("applyDefaults() overrode resource values: %+v", opts)
+	}
+	if opts.PACRepository != config.PACRepository || opts.LogPath != "logs" || opts.EnginePath != config.EnginePath || opts.PACCache != config.PACCache {
+		t.Errorf("applyDefaults() did not inherit provider values: %+v", opts)
+	}
+
+	opts = ScanOptions{}
+	config.applyDefaults(&opts)
+	if opts.Threshold == nil || *opts.Threshold != 80 {
+		t.Errorf("applyDefaults() threshold = %v, want 80", opts.Threshold)
+	}
+
+	var unset *ProviderConfig
+	opts = ScanOptions{}
+	unset.applyDefaults(&opts)
+	if opts.PACVersion != "" || opts.LogPath != "" || opts.Threshold != nil {
+		t.Errorf("applyDefaults() of a nil config changed the options: %+v", opts)
+	}
+}
@@ -0,0 +1,54 @@
+# Subset of the Fugue rule library used by regula, so that rules written for
+# regula can be evaluated by the native engine.
+package fugue
+
+# input_type is the type of the scanned input, "tf" or "tf_plan", the native
+# engine passes it as data.scan.input_type
+default input_type := "tf"
+
+input_type := data.scan.input_type
+
+resource_types_v0 := {resource_type | resource_type := input.resources[_]._type}
+
+resources(resource_type) := {id: resource |
+	resource := input.resources[id]
+	resource._type == resource_type
+}
+
+judgement_from_resource(resource, valid, message) := {
+	"valid": valid,
+	"id": resource.id,
+	"message": message,
+	"type": resource._type,
+}
+
+allow_resource(resource) := judgement_from_resource(resource, true, "")
+
+deny_resource(resource) := judgement_from_resource(resource, false, "")
+
+deny_resource_with_message(resource, message) := judgement_from_resource(resource, false, message)
+
+missing_resource(resource_type) := {
+	"valid": false,
+	"id": "",
+	"message": "",
+	"type": resource_type,
+}
+
+missing_resource_with_message(resource_type, message) := {
+	"valid": false,
+	"id": "",
+	"message": message,
+	"type": resource_type,
+}
+
+allow(params) := judgement_from_resource(params.resource, true, object.get(params, "message", ""))
+
+deny(params) := judgement_from_resource(params.resource, false, object.get(params, "message", ""))
+
+missing(params) := {
+	"valid": false,
+	"id": "",
+	"message": object.get(params, "message", ""),
+	"type": params.resource_type,
+}
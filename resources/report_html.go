@@ -0,0 +1,189 @@
+package resources
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+)
+
+// htmlReportTemplate renders the self-contained HTML report, styles and
+// scripts are inlined so the file can be archived and shared on its own.
+//
+//go:embed reportlib/report.html.tmpl
+var htmlReportTemplate string
+
+var htmlReport = template.Must(template.New("report").Parse(htmlReportTemplate))
+
+// htmlReportData is the input of htmlReportTemplate.
+type htmlReportData struct {
+	Generated  string
+	Filter     string
+	Score      ScoreSummary
+	Severities []htmlSeverity
+	Compliance []FrameworkCompliance
+	Findings   []htmlFinding
+	Rules      []htmlRule
+}
+
+// htmlSeverity is a bar of the severity histogram. Width is relative to the
+// severity with the most results, the shares are relative to Width.
+type htmlSeverity struct {
+	Name                                  string
+	Passed, Failed, Waived                int64
+	Width                                 float64
+	FailedShare, PassedShare, WaivedShare float64
+}
+
+type htmlFinding struct {
+	RuleID, RuleName string
+	Severity, Result string
+	ResourceID       string
+	ResourceType     string
+	Location         string
+	Message          string
+	Waiver           string
+}
+
+type htmlRule struct {
+	ID, Name             string
+	Severity             string
+	Summary, Description string
+	Controls, Families   []string
+}
+
+// describe fills the metadata of the rule missing so far from one of its results.
+func (r *htmlRule) describe(rule RegulaRuleResult) {
+	if r.Name == "" {
+		r.Name = rule.RuleName
+	}
+	if r.Summary == "" {
+		r.Summary = rule.RuleSummary
+	}
+	if r.Description == "" {
+		r.Description = rule.RuleDescription
+	}
+	if len(r.Controls) == 0 {
+		r.Controls = rule.Controls
+	}
+	if len(r.Families) == 0 {
+		r.Families = rule.Families
+	}
+}
+
+// renderHTML converts the scan into a single file HTML report with the score,
+// a severity histogram, the compliance of every framework per control, a
+// filterable findings table and the descriptions of the evaluated rules.
+func renderHTML(report scanReport) ([]byte, error) {
+	data := htmlReportData{
+		Generated:  time.Now().Format(time.RFC1123),
+		Filter:     report.Filter.String(),
+		Score:      report.Score,
+		Severities: htmlSeverities(report.Score),
+		Compliance: calculateCompliance(report.Output.RuleResults),
+		Findings:   []htmlFinding{},
+		Rules:      []htmlRule{},
+	}
+
+	rules := map[string]int{}
+	for _, rule := range sortedRuleResults(report.Output.RuleResults) {
+		severity := normalizeSeverity(rule.RuleSeverity)
+		data.Findings = append(data.Findings, newHTMLFinding(rule, severity))
+
+		index, ok := rules[rule.RuleID]
+		if !ok {
+			index = len(data.Rules)
+			rules[rule.RuleID] = index
+			data.Rules = append(data.Rules, htmlRule{ID: rule.RuleID, Severity: severity})
+		}
+		data.Rules[index].describe(rule)
+	}
+	// Failures first, most severe first
+	sort.SliceStable(data.Findings, func(i, j int) bool {
+		if htmlResultRank(data.Findings[i].Result) != htmlResultRank(data.Findings[j].Result) {
+			return htmlResultRank(data.Findings[i].Result) < htmlResultRank(data.Findings[j].Result)
+		}
+		return severityRank(data.Findings[i].Severity) < severityRank(data.Findings[j].Severity)
+	})
+
+	var content bytes.Buffer
+	if err := htmlReport.Execute(&content, data); err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// htmlSeverities returns the histogram bars in the order of Severities,
+// followed by unknown severities when there are any.
+func htmlSeverities(score ScoreSummary) []htmlSeverity {
+	names := append([]string{}, Severities...)
+	if _, ok := score.Severities[severityUnknown]; ok {
+		names = append(names, severityUnknown)
+	}
+
+	var most int64
+	for _, bucket := range score.Severities {
+		if total := bucket.Passed + bucket.Failed + bucket.Waived; total > most {
+			most = total
+		}
+	}
+
+	severities := []htmlSeverity{}
+	for _, name := range names {
+		bucket := score.Severities[name]
+		severity := htmlSeverity{Name: name, Passed: bucket.Passed, Failed: bucket.Failed, Waived: bucket.Waived}
+		if total := bucket.Passed + bucket.Failed + bucket.Waived; total > 0 {
+			severity.Width = 100 * float64(total) / float64(most)
+			severity.FailedShare = 100 * float64(bucket.Failed) / float64(total)
+			severity.PassedShare = 100 * float64(bucket.Passed) / float64(total)
+			severity.WaivedShare = 100 * float64(bucket.Waived) / float64(total)
+		}
+		severities = append(severities, severity)
+	}
+	return severities
+}
+
+func newHTMLFinding(rule RegulaRuleResult, severity string) htmlFinding {
+	file, startLine, endLine := rule.location()
+	location := file
+	if startLine > 0 {
+		location = fmt.Sprintf("%s:%d-%d", file, startLine, endLine)
+	}
+	message := rule.RuleMessage
+	if message == "" {
+		message = rule.RuleSummary
+	}
+
+	finding := htmlFinding{
+		RuleID:       rule.RuleID,
+		RuleName:     rule.RuleName,
+		Severity:     severity,
+		Result:       rule.RuleResult,
+		ResourceID:   rule.ResourceID,
+		ResourceType: rule.ResourceType,
+		Location:     location,
+		Message:      message,
+	}
+	if rule.Waiver != nil {
+		finding.Waiver = rule.Waiver.Reason
+		if rule.Waiver.Owner != "" {
+			finding.Waiver += fmt.Sprintf(" (%s)", rule.Waiver.Owner)
+		}
+	}
+	return finding
+}
+
+// htmlResultRank orders the findings table, lower comes first.
+func htmlResultRank(result string) int {
+	switch result {
+	case "FAIL":
+		return 0
+	case "WAIVED":
+		return 1
+	case "PASS":
+		return 2
+	}
+	return 3
+}
@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	output := RegulaOutput{RuleResults: []RegulaRuleResult{
+		{
+			RuleID:          "S3.1",
+			RuleName:        "s3_public_access",
+			RuleSummary:     "S3 buckets block public access",
+			RuleDescription: "Public access to S3 buckets must be blocked.",
+			RuleSeverity:    "High",
+			RuleResult:      "FAIL",
+			RuleMessage:     "bucket <script>alert(1)</script> is public",
+			ResourceID:      "aws_s3_bucket.public",
+			ResourceType:    "aws_s3_bucket",
+			Controls:        []string{"CIS-AWS_v1.4.0_2.1.5"},
+			Families:        []string{"CIS-AWS_v1.4.0"},
+			SourceLocation:  []RegulaSourceLocation{{Path: "main.tf", Line: 3, EndLine: 7}},
+		},
+		{RuleID: "S3.1", RuleSeverity: "High", RuleResult: "PASS", ResourceID: "aws_s3_bucket.private", Controls: []string{"CIS-AWS_v1.4.0_2.1.5"}},
+		{
+			RuleID:       "EC2.1",
+			RuleSeverity: "Low",
+			RuleResult:   "WAIVED",
+			ResourceID:   "aws_instance.web",
+			Controls:     []string{"CIS-AWS_v1.4.0_5.6"},
+			Waiver:       &Waiver{RuleID: "EC2.1", Reason: "legacy AMI", Owner: "platform-team"},
+		},
+	}}
+	score := calculateScore(output, ScoringModel{Mode: ScoringModeFlat})
+
+	content, err := renderHTML(scanReport{Output: output, Score: score})
+	if err != nil {
+		t.Fatalf("renderHTML() error = %v", err)
+	}
+	html := string(content)
+
+	for _, want := range []string{
+		"50.00%",
+		"CIS-AWS_v1.4.0 <span class=\"muted\">0.00%",
+		"<td>2.1.5</td>",
+		`id="rule-S3.1"`,
+		"Public access to S3 buckets must be blocked.",
+		"main.tf:3-7",
+		"legacy AMI (platform-team)",
+		"bucket &lt;script&gt;alert(1)&lt;/script&gt; is public",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("renderHTML() does not contain %q", want)
+		}
+	}
+	// The report is a single file without external assets
+	if external := regexp.MustCompile(`(?i)(src|href)="(https?:)?//|<link`).FindString(html); external != "" {
+		t.Errorf("renderHTML() references an external asset: %s", external)
+	}
+}
+
+func TestHTMLReportData(t *testing.T) {
+	score := ScoreSummary{Severities: map[string]SeverityScore{
+		"High": {Passed: 1, Failed: 3},
+		"Low":  {Waived: 2},
+	}}
+	severities := htmlSeverities(score)
+	if len(severities) != len(Severities) {
+		t.Fatalf("htmlSeverities() = %+v, want a bar per severity", severities)
+	}
+	high, low := severities[1], severities[3]
+	if high.Width != 100 || high.FailedShare != 75 || high.PassedShare != 25 || low.Width != 50 || low.WaivedShare != 100 {
+		t.Errorf("htmlSeverities() high = %+v, low = %+v", high, low)
+	}
+
+}
@@ -0,0 +1,147 @@
+package resources
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+	"time"
+)
+
+// junitUngrouped names the test suite of rules without a family.
+const junitUngrouped = "Ungrouped"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure"`
+	Skipped   *junitSkipped `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// renderJUnit converts the scan into a JUnit XML report with a test case per
+// rule and resource, grouped into a test suite per rule family. A rule in
+// several families has its test cases in each of their suites. Failures are
+// failed test cases and waived failures skipped ones.
+func renderJUnit(report scanReport) ([]byte, error) {
+	suites := map[string]*junitTestSuite{}
+	for _, rule := range sortedRuleResults(report.Output.RuleResults) {
+		families := rule.Families
+		if len(families) == 0 {
+			families = []string{junitUngrouped}
+		}
+		testCase := newJUnitTestCase(rule)
+		for _, family := range families {
+			suite, ok := suites[family]
+			if !ok {
+				suite = &junitTestSuite{Name: family, TestCases: []junitTestCase{}}
+				suites[family] = suite
+			}
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+	}
+
+	root := junitTestSuites{Name: "starchitect", Suites: []junitTestSuite{}}
+	for _, family := range utils.SortedKeys(suites) {
+		suite := suites[family]
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Skipped += suite.Skipped
+		root.Suites = append(root.Suites, *suite)
+	}
+
+	content, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func newJUnitTestCase(rule RegulaRuleResult) junitTestCase {
+	file, startLine, _ := rule.location()
+	testCase := junitTestCase{
+		Name:      rule.ResourceID,
+		ClassName: rule.RuleID,
+		File:      file,
+		Line:      startLine,
+	}
+	if rule.RuleName != "" {
+		testCase.ClassName = fmt.Sprintf("%s %s", rule.RuleID, rule.RuleName)
+	}
+
+	switch rule.RuleResult {
+	case "FAIL":
+		message := rule.RuleMessage
+		if message == "" {
+			message = rule.RuleSummary
+		}
+		details := []string{fmt.Sprintf("Resource: %s", rule.ResourceID)}
+		if file != "" {
+			location := file
+			if startLine > 0 {
+				location = fmt.Sprintf("%s:%d", file, startLine)
+			}
+			details = append(details, fmt.Sprintf("Location: %s", location))
+		}
+		if rule.RuleSummary != "" {
+			details = append(details, fmt.Sprintf("Summary: %s", rule.RuleSummary))
+		}
+		if len(rule.Controls) > 0 {
+			details = append(details, fmt.Sprintf("Controls: %s", strings.Join(rule.Controls, ", ")))
+		}
+		testCase.Failure = &junitFailure{
+			Message: message,
+			Type:    normalizeSeverity(rule.RuleSeverity),
+			Text:    strings.Join(details, "\n"),
+		}
+	case "WAIVED":
+		message := "waived"
+		if rule.Waiver != nil {
+			message = fmt.Sprintf("waived: %s", rule.Waiver.Reason)
+			if rule.Waiver.Owner != "" {
+				message += fmt.Sprintf(", owner %s", rule.Waiver.Owner)
+			}
+			if !rule.Waiver.Expires.IsZero() {
+				message += fmt.Sprintf(", expires %s", rule.Waiver.Expires.Format(time.RFC3339))
+			}
+		}
+		testCase.Skipped = &junitSkipped{Message: message}
+	}
+	return testCase
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRenderJUnit(t *testing.T) {
+	output := RegulaOutput{RuleResults: []RegulaRuleResult{
+		{
+			RuleID:         "S3.1",
+			RuleName:       "s3_public_access",
+			RuleSummary:    "S3 buckets block public access",
+			RuleSeverity:   "High",
+			RuleResult:     "FAIL",
+			RuleMessage:    "bucket is public",
+			ResourceID:     "aws_s3_bucket.public",
+			Filepath:       "main.tf",
+			Families:       []string{"CIS-AWS_v1.4.0", "NIST-800-53"},
+			SourceLocation: []RegulaSourceLocation{{Path: "main.tf", Line: 3, EndLine: 7}},
+		},
+		{RuleID: "S3.1", RuleName: "s3_public_access", RuleResult: "PASS", ResourceID: "aws_s3_bucket.private", Families: []string{"CIS-AWS_v1.4.0"}},
+		{
+			RuleID:     "EC2.1",
+			RuleResult: "WAIVED",
+			ResourceID: "aws_instance.web",
+			Families:   []string{"AWS-Foundational-Security"},
+			Waiver:     &Waiver{RuleID: "EC2.1", Reason: "legacy AMI", Owner: "platform-team"},
+		},
+		{RuleID: "IAM.1", RuleResult: "PASS", ResourceID: "aws_iam_role.ci"},
+	}}
+
+	content, err := renderJUnit(scanReport{Output: output})
+	if err != nil {
+		t.Fatalf("renderJUnit() error = %v", err)
+	}
+	if !strings.HasPrefix(string(content), xml.Header) {
+		t.Errorf("renderJUnit() is missing the XML header")
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(content, &report); err != nil {
+		t.Fatalf("renderJUnit() is not valid XML: %v", err)
+	}
+	if report.Tests != 5 || report.Failures != 2 || report.Skipped != 1 || len(report.Suites) != 4 {
+		t.Fatalf("renderJUnit() = %d tests, %d failures, %d skipped in %d suites, want 5, 2, 1 in 4",
+			report.Tests, report.Failures, report.Skipped, len(report.Suites))
+	}
+
+	waivedSuite, cisSuite, nistSuite, ungrouped := report.Suites[0], report.Suites[1], report.Suites[2], report.Suites[3]
+	if waivedSuite.Name != "AWS-Foundational-Security" || waivedSuite.Skipped != 1 ||
+		waivedSuite.TestCases[0].Skipped == nil || waivedSuite.TestCases[0].Skipped.Message != "waived: legacy AMI, owner platform-team" {
+		t.Errorf("renderJUnit() waived suite = %+v", waivedSuite)
+	}
+	if nistSuite.Name != "NIST-800-53" || nistSuite.Tests != 1 || nistSuite.Failures != 1 || nistSuite.TestCases[0].Name != "aws_s3_bucket.public" {
+		t.Errorf("renderJUnit() NIST suite = %+v", nistSuite)
+	}
+	if ungrouped.Name != junitUngrouped || ungrouped.Tests != 1 || ungrouped.TestCases[0].Failure != nil {
+		t.Errorf("renderJUnit() ungrouped suite = %+v", ungrouped)
+	}
+
+	if cisSuite.Name != "CIS-AWS_v1.4.0" || cisSuite.Tests != 2 || cisSuite.Failures != 1 {
+		t.Fatalf("renderJUnit() CIS suite = %+v", cisSuite)
+	}
+	passed, failed := cisSuite.TestCases[0], cisSuite.TestCases[1]
+	if passed.Name != "aws_s3_bucket.private" || passed.Failure != nil || passed.Skipped != nil {
+		t.Errorf("renderJUnit() passed test case = %+v", passed)
+	}
+	if failed.Name != "aws_s3_bucket.public" || failed.ClassName != "S3.1 s3_public_access" || failed.File != "main.tf" || failed.Line != 3 ||
+		failed.Failure == nil || failed.Failure.Message != "bucket is public" || failed.Failure.Type != "High" ||
+		!strings.Contains(failed.Failure.Text, "Resource: aws_s3_bucket.public") || !strings.Contains(failed.Failure.Text, "Location: main.tf:3") {
+		t.Errorf("renderJUnit() failed test case = %+v, failure %+v", failed, failed.Failure)
+	}
+}
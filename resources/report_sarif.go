@@ -0,0 +1,209 @@
+package resources
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLevels maps the rule severities to SARIF levels, unknown severities
+// are reported as warnings.
+var sarifLevels = map[string]string{
+	"Critical":      "error",
+	"High":          "error",
+	"Medium":        "warning",
+	"Low":           "note",
+	"Informational": "note",
+}
+
+// sarifSecuritySeverities are the security-severity scores code scanning
+// dashboards rank the alerts by.
+var sarifSecuritySeverities = map[string]string{
+	"Critical":      "9.5",
+	"High":          "8.0",
+	"Medium":        "5.5",
+	"Low":           "3.0",
+	"Informational": "0.0",
+	severityUnknown: "5.5",
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     *sarifMessage       `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity"`
+	Severity         string   `json:"severity"`
+	Controls         []string `json:"controls,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// renderSARIF converts the scan into a SARIF log. Every evaluated rule is
+// listed in the driver, failures are reported as results and waived failures
+// as suppressed results.
+func renderSARIF(report scanReport) ([]byte, error) {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "starchitect", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	for _, rule := range sortedRuleResults(report.Output.RuleResults) {
+		index, ok := ruleIndexes[rule.RuleID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndexes[rule.RuleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(rule))
+		}
+		if rule.RuleResult != "FAIL" && rule.RuleResult != "WAIVED" {
+			continue
+		}
+		run.Results = append(run.Results, newSARIFResult(rule, index))
+	}
+
+	return json.MarshalIndent(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}, "", "  ")
+}
+
+func newSARIFRule(rule RegulaRuleResult) sarifRule {
+	severity := normalizeSeverity(rule.RuleSeverity)
+	sarif := sarifRule{
+		ID:                   rule.RuleID,
+		Name:                 rule.RuleName,
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(severity)},
+		Properties: sarifRuleProperties{
+			Tags:             append([]string{"security"}, rule.Families...),
+			SecuritySeverity: sarifSecuritySeverities[severity],
+			Severity:         severity,
+			Controls:         rule.Controls,
+		},
+	}
+	if rule.RuleSummary != "" {
+		sarif.ShortDescription = &sarifMessage{Text: rule.RuleSummary}
+	}
+	if rule.RuleDescription != "" {
+		sarif.FullDescription = &sarifMessage{Text: rule.RuleDescription}
+	}
+	return sarif
+}
+
+func newSARIFResult(rule RegulaRuleResult, ruleIndex int) sarifResult {
+	message := rule.RuleMessage
+	if message == "" {
+		message = rule.RuleSummary
+	}
+	if message == "" {
+		message = rule.RuleID
+	}
+
+	result := sarifResult{
+		RuleID:    rule.RuleID,
+		RuleIndex: ruleIndex,
+		Level:     sarifLevel(normalizeSeverity(rule.RuleSeverity)),
+		Message:   sarifMessage{Text: message},
+	}
+
+	location := sarifLocation{}
+	file, startLine, endLine := rule.location()
+	if file != "" {
+		location.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: strings.TrimPrefix(filepath.ToSlash(file), "./")},
+		}
+		if startLine > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: startLine, EndLine: endLine}
+		}
+	}
+	if rule.ResourceID != "" {
+		location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: rule.ResourceID, Kind: "resource"}}
+	}
+	if location.PhysicalLocation != nil || location.LogicalLocations != nil {
+		result.Locations = []sarifLocation{location}
+	}
+
+	if rule.Waiver != nil {
+		result.Suppressions = []sarifSuppression{{Kind: "external", Justification: rule.Waiver.Reason}}
+	}
+	return result
+}
+
+func sarifLevel(severity string) string {
+	if level, ok := sarifLevels[severity]; ok {
+		return level
+	}
+	return "warning"
+}
@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRenderSARIF(t *testing.T) {
+	s3Rule := RegulaRuleResult{
+		RuleID:          "S3.1",
+		RuleName:        "s3_public_access",
+		RuleSummary:     "S3 buckets block public access",
+		RuleDescription: "Public access to S3 buckets must be blocked.",
+		RuleSeverity:    "high",
+		Controls:        []string{"CIS-AWS_v1.4.0_2.1.5"},
+		Families:        []string{"CIS-AWS_v1.4.0"},
+	}
+	public, private := s3Rule, s3Rule
+	public.RuleResult, public.RuleMessage, public.ResourceID, public.Filepath = "FAIL", "bucket is public", "aws_s3_bucket.public", "./main.tf"
+	public.SourceLocation = []RegulaSourceLocation{{Path: "main.tf", Line: 3, EndLine: 7}}
+	private.RuleResult, private.ResourceID, private.Filepath = "PASS", "aws_s3_bucket.private", "main.tf"
+
+	output := RegulaOutput{RuleResults: []RegulaRuleResult{
+		public,
+		private,
+		{
+			RuleID:       "EC2.1",
+			RuleSummary:  "Instances use IMDSv2",
+			RuleSeverity: "Low",
+			RuleResult:   "WAIVED",
+			ResourceID:   "aws_instance.web",
+			Filepath:     "modules/web/main.tf",
+			Waiver:       &Waiver{RuleID: "EC2.1", Reason: "legacy AMI"},
+		},
+		{RuleID: "IAM.1", RuleSeverity: "Critical", RuleResult: "PASS", ResourceID: "aws_iam_role.ci"},
+	}}
+
+	content, err := renderSARIF(scanReport{Output: output})
+	if err != nil {
+		t.Fatalf("renderSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(content, &log); err != nil {
+		t.Fatalf("renderSARIF() is not valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("renderSARIF() = version %q with %d runs, want one 2.1.0 run", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	ruleIDs := []string{}
+	for _, rule := range run.Tool.Driver.Rules {
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+	if want := []string{"EC2.1", "IAM.1", "S3.1"}; !reflect.DeepEqual(ruleIDs, want) {
+		t.Errorf("renderSARIF() rules = %v, want %v", ruleIDs, want)
+	}
+	rule := run.Tool.Driver.Rules[2]
+	if rule.DefaultConfiguration.Level != "error" || rule.Properties.SecuritySeverity != "8.0" ||
+		!reflect.DeepEqual(rule.Properties.Controls, []string{"CIS-AWS_v1.4.0_2.1.5"}) ||
+		!reflect.DeepEqual(rule.Properties.Tags, []string{"security", "CIS-AWS_v1.4.0"}) ||
+		rule.FullDescription == nil || rule.FullDescription.Text != "Public access to S3 buckets must be blocked." {
+		t.Errorf("renderSARIF() S3.1 rule = %+v", rule)
+	}
+
+	// PASS results are not reported, waived failures are suppressed
+	if len(run.Results) != 2 {
+		t.Fatalf("renderSARIF() results = %+v, want 2", run.Results)
+	}
+	waived, failed := run.Results[0], run.Results[1]
+	if waived.RuleID != "EC2.1" || waived.RuleIndex != 0 || waived.Level != "note" || waived.Message.Text != "Instances use IMDSv2" ||
+		!reflect.DeepEqual(waived.Suppressions, []sarifSuppression{{Kind: "external", Justification: "legacy AMI"}}) {
+		t.Errorf("renderSARIF() waived result = %+v", waived)
+	}
+	wantLocation := sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "main.tf"},
+			Region:           &sarifRegion{StartLine: 3, EndLine: 7},
+		},
+		LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "aws_s3_bucket.public", Kind: "resource"}},
+	}
+	if failed.RuleID != "S3.1" || failed.RuleIndex != 2 || failed.Level != "error" || failed.Message.Text != "bucket is public" ||
+		len(failed.Locations) != 1 || !reflect.DeepEqual(failed.Locations[0], wantLocation) || failed.Suppressions != nil {
+		t.Errorf("renderSARIF() failed result = %+v", failed)
+	}
+}
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Report formats written to log_path, selected through report_formats.
+const (
+	// ReportRaw is the JSON output of the engine.
+	ReportRaw = "raw"
+	// ReportSummary is the human readable summary also stored in scan_result.
+	ReportSummary = "summary"
+	// ReportSARIF is a SARIF 2.1.0 log for code scanning dashboards.
+	ReportSARIF = "sarif"
+	// ReportJUnit is a JUnit XML report for CI test dashboards.
+	ReportJUnit = "junit"
+	// ReportHTML is a self-contained HTML report for auditors.
+	ReportHTML = "html"
+)
+
+// ReportFormats lists the supported values of report_formats.
+var ReportFormats = []string{ReportRaw, ReportSummary, ReportSARIF, ReportJUnit, ReportHTML}
+
+// DefaultReportFormats are written when report_formats is unset.
+var DefaultReportFormats = []string{ReportRaw, ReportSummary}
+
+// scanReport holds the outputs of a scan the report files are rendered from.
+type scanReport struct {
+	// Raw is the output of the engine as it was returned.
+	Raw string
+	// Summary is the output of formatRegulaOutput.
+	Summary string
+	// Output is the parsed output of the engine with the waivers applied.
+	Output RegulaOutput
+	Score  ScoreSummary
+	// Filter is the rule filter the scan ran with.
+	Filter RuleFilter
+}
+
+// reportWriter renders a report format into a log file.
+type reportWriter struct {
+	// suffix is appended to the timestamp to name the log file.
+	suffix string
+	render func(report scanReport) ([]byte, error)
+}
+
+var reportWriters = map[string]reportWriter{
+	ReportRaw: {
+		suffix: "starchitect_raw.json",
+		render: func(report scanReport) ([]byte, error) { return []byte(report.Raw), nil },
+	},
+	ReportSummary: {
+		suffix: "starchitect_summary.log",
+		render: func(report scanReport) ([]byte, error) { return []byte(report.Summary), nil },
+	},
+	ReportSARIF: {
+		suffix: "starchitect.sarif",
+		render: renderSARIF,
+	},
+	ReportJUnit: {
+		suffix: "starchitect_junit.xml",
+		render: renderJUnit,
+	},
+	ReportHTML: {
+		suffix: "starchitect_report.html",
+		render: renderHTML,
+	},
+}
+
+// writeReports writes a log file per report format into logPath, the working
+// directory when empty. Formats default to DefaultReportFormats.
+func writeReports(report scanReport, formats []string, logPath string) error {
+	if formats == nil {
+		formats = DefaultReportFormats
+	}
+	timestamp := time.Now().Format("20060102_150405")
+
+	// Create log directory if it doesn't exist
+	if logPath != "" {
+		if err := os.MkdirAll(logPath, 0755); err != nil {
+			return fmt.Errorf("failed to create log directory: %v", err)
+		}
+	}
+
+	for _, format := range formats {
+		writer, ok := reportWriters[format]
+		if !ok {
+			return fmt.Errorf("unknown report format %q", format)
+		}
+		content, err := writer.render(report)
+		if err != nil {
+			return fmt.Errorf("failed to render the %s report: %v", format, err)
+		}
+		fileName := filepath.Join(logPath, fmt.Sprintf("%s_%s", timestamp, writer.suffix))
+		if err := os.WriteFile(fileName, content, 0644); err != nil {
+			return fmt.Errorf("failed to write the %s report: %v", format, err)
+		}
+	}
+	return nil
+}
+
+// sortedRuleResults orders the results by rule and resource so that reports of
+// the same scan are identical.
+func sortedRuleResults(results []RegulaRuleResult) []RegulaRuleResult {
+	sorted := append([]RegulaRuleResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RuleID != sorted[j].RuleID {
+			return sorted[i].RuleID < sorted[j].RuleID
+		}
+		return sorted[i].ResourceID < sorted[j].ResourceID
+	})
+	return sorted
+}
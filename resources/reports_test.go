@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWriteReports(t *testing.T) {
+	report := scanReport{Raw: `{"rule_results": []}`, Summary: "Summary\n", Output: RegulaOutput{}}
+
+	tests := []struct {
+		name         string
+		formats      []string
+		wantSuffixes []string
+	}{
+		{
+			name:         "default formats",
+			wantSuffixes: []string{"starchitect_raw.json", "starchitect_summary.log"},
+		},
+		{
+			name:         "sarif and junit",
+			formats:      []string{ReportSARIF, ReportJUnit},
+			wantSuffixes: []string{"starchitect.sarif", "starchitect_junit.xml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "logs")
+			if err := writeReports(report, tt.formats, logPath); err != nil {
+				t.Fatalf("writeReports() error = %v", err)
+			}
+			entries, err := os.ReadDir(logPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, entry := range entries {
+				// Strip the timestamp prefix of the file names
+				got = append(got, strings.SplitN(entry.Name(), "_", 3)[2])
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantSuffixes) {
+				t.Errorf("writeReports() wrote %v, want %v", got, tt.wantSuffixes)
+			}
+		})
+	}
+
+	if err := writeReports(report, []string{"pdf"}, t.TempDir()); err == nil {
+		t.Errorf("writeReports(pdf) error = nil, want an unknown format error")
+	}
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RuleFilter selects the rules a scan evaluates. The engines apply it to the
+// metadata of the compiled rules before any resource is evaluated, unset
+// fields select every rule.
+type RuleFilter struct {
+	// IncludeRules are path.Match globs of the rule IDs or names to evaluate.
+	IncludeRules []string
+	// ExcludeRules are path.Match globs of the rule IDs or names to skip.
+	ExcludeRules []string
+	// IncludeFrameworks select the rules with a family of the framework,
+	// e.g. CIS matches CIS-AWS_v1.4.0 and CIS-AWS-Compute-Services-Benchmark_v1.0.0.
+	IncludeFrameworks []string
+	// MinSeverity skips the rules less severe than MinSeverity, one of
+	// Severities. Unknown severities rank like Low.
+	MinSeverity string
+	// Families select the rules reporting one of the families, e.g. CIS-AWS_v1.4.0.
+	Families []string
+}
+
+// ruleMetadata is what a RuleFilter matches rules by.
+type ruleMetadata struct {
+	ID       string
+	Name     string
+	Severity string
+	Families []string
+}
+
+// IsEmpty reports whether the filter selects every rule.
+func (f RuleFilter) IsEmpty() bool {
+	return len(f.IncludeRules) == 0 && len(f.ExcludeRules) == 0 && len(f.IncludeFrameworks) == 0 &&
+		f.MinSeverity == "" && len(f.Families) == 0
+}
+
+// selects reports whether the rule is evaluated.
+func (f RuleFilter) selects(rule ruleMetadata) bool {
+	if len(f.IncludeRules) > 0 && !matchesRule(f.IncludeRules, rule) {
+		return false
+	}
+	if matchesRule(f.ExcludeRules, rule) {
+		return false
+	}
+	if len(f.IncludeFrameworks) > 0 && !matchesFramework(f.IncludeFrameworks, rule.Families) {
+		return false
+	}
+	if f.MinSeverity != "" && severityRank(normalizeSeverity(rule.Severity)) > severityRank(normalizeSeverity(f.MinSeverity)) {
+		return false
+	}
+	if len(f.Families) > 0 && !matchesFamily(f.Families, rule.Families) {
+		return false
+	}
+	return true
+}
+
+func matchesRule(patterns []string, rule ruleMetadata) bool {
+	for _, pattern := range patterns {
+		for _, name := range []string{rule.ID, rule.Name} {
+			if name == "" {
+				continue
+			}
+			if matched, err := path.Match(pattern, name); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesFramework reports whether a family belongs to one of the frameworks,
+// i.e. equals it or continues it with a dash or underscore, ignoring case.
+func matchesFramework(frameworks, families []string) bool {
+	for _, framework := range frameworks {
+		framework = strings.ToLower(framework)
+		for _, family := range families {
+			family = strings.ToLower(family)
+			if family == framework || strings.HasPrefix(family, framework+"-") || strings.HasPrefix(family, framework+"_") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesFamily(want, families []string) bool {
+	for _, family := range families {
+		for _, wanted := range want {
+			if strings.EqualFold(family, wanted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String renders the active filters as recorded in the reports, empty when
+// every rule is selected.
+func (f RuleFilter) String() string {
+	filters := []string{}
+	for _, filter := range []struct {
+		name   string
+		values []string
+	}{
+		{"include_rules", f.IncludeRules},
+		{"exclude_rules", f.ExcludeRules},
+		{"include_frameworks", f.IncludeFrameworks},
+		{"families", f.Families},
+	} {
+		if len(filter.values) > 0 {
+			filters = append(filters, fmt.Sprintf("%s: %s", filter.name, strings.Join(filter.values, ", ")))
+		}
+	}
+	if f.MinSeverity != "" {
+		filters = append(filters, fmt.Sprintf("min_severity: %s", f.MinSeverity))
+	}
+	return strings.Join(filters, "; ")
+}
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRuleFilterSelects(t *testing.T) {
+	s3 := ruleMetadata{ID: "S3.1", Name: "s3_public_access", Severity: "High", Families: []string{"CIS-AWS_v1.4.0"}}
+	ec2 := ruleMetadata{ID: "EC2.1", Name: "ec2_imdsv2", Severity: "critical", Families: []string{"SOC2"}}
+	tags := ruleMetadata{ID: "TAG.1", Name: "tags_required", Severity: "Unknown"}
+	compute := ruleMetadata{ID: "EC2.2", Name: "ec2_public_ip", Severity: "Medium", Families: []string{"CIS-AWS-Compute-Services-Benchmark_v1.0.0"}}
+	rules := []ruleMetadata{s3, ec2, tags, compute}
+
+	tests := []struct {
+		name   string
+		filter RuleFilter
+		want   []string
+	}{
+		{name: "empty", filter: RuleFilter{}, want: []string{"S3.1", "EC2.1", "TAG.1", "EC2.2"}},
+		{name: "include rule ID glob", filter: RuleFilter{IncludeRules: []string{"EC2.*"}}, want: []string{"EC2.1", "EC2.2"}},
+		{name: "include rule name", filter: RuleFilter{IncludeRules: []string{"tags_required"}}, want: []string{"TAG.1"}},
+		{name: "exclude rules", filter: RuleFilter{ExcludeRules: []string{"EC2.*", "[invalid"}}, want: []string{"S3.1", "TAG.1"}},
+		{name: "include frameworks", filter: RuleFilter{IncludeFrameworks: []string{"cis"}}, want: []string{"S3.1", "EC2.2"}},
+		{name: "include framework with version", filter: RuleFilter{IncludeFrameworks: []string{"CIS-AWS_v1.4.0"}}, want: []string{"S3.1"}},
+		{name: "min severity", filter: RuleFilter{MinSeverity: "High"}, want: []string{"S3.1", "EC2.1"}},
+		{name: "min severity ranks unknown like low", filter: RuleFilter{MinSeverity: "Low"}, want: []string{"S3.1", "EC2.1", "TAG.1", "EC2.2"}},
+		{name: "families", filter: RuleFilter{Families: []string{"SOC2"}}, want: []string{"EC2.1"}},
+		{
+			name:   "CIS only, High and above",
+			filter: RuleFilter{IncludeFrameworks: []string{"CIS"}, MinSeverity: "High"},
+			want:   []string{"S3.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, rule := range rules {
+				if tt.filter.selects(rule) {
+					got = append(got, rule.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	filter := RuleFilter{IncludeFrameworks: []string{"CIS"}, MinSeverity: "High", ExcludeRules: []string{"S3.2", "S3.3"}}
+	if got, want := filter.String(), "exclude_rules: S3.2, S3.3; include_frameworks: CIS; min_severity: High"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if !(RuleFilter{}).IsEmpty() || filter.IsEmpty() {
+		t.Errorf("IsEmpty() does not tell an empty filter apart")
+	}
+
+	// The active filters are recorded in the summary
+	if summary := formatRegulaOutput(RegulaOutput{}, filter); !strings.Contains(summary, "Rule Filters: "+filter.String()+"\n") {
+		t.Errorf("formatRegulaOutput() does not record the filters:\n%s", summary)
+	}
+	if summary := formatRegulaOutput(RegulaOutput{}, RuleFilter{}); strings.Contains(summary, "Rule Filters") {
+		t.Errorf("formatRegulaOutput() records an empty filter:\n%s", summary)
+	}
+}
+
+// writeFilterFixture writes an IaC with a bucket and a PAC with rules of different severities and families.
+func writeFilterFixture(t *testing.T) (string, string) {
+	t.Helper()
+	iacDir := t.TempDir()
+	pacDir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(iacDir, "main.tf"): "resource \"aws_s3_bucket\" \"logs\" {\n  acl = \"private\"\n}\n",
+	}
+	for name, metadoc := range map[string]string{
+		"s3_private":   `{"id": "S3.1", "title": "", "description": "", "custom": {"controls": {"CIS-AWS_v1.4.0": ["CIS-AWS_v1.4.0_2.1.5"]}, "severity": "High"}}`,
+		"s3_logging":   `{"id": "S3.2", "title": "", "description": "", "custom": {"controls": {"CIS-AWS_v1.4.0": ["CIS-AWS_v1.4.0_3.6"]}, "severity": "Low"}}`,
+		"s3_versioned": `{"id": "S3.3", "title": "", "description": "", "custom": {"controls": {"SOC2": ["SOC2_A1.2"]}, "severity": "Critical"}}`,
+	} {
+		files[filepath.Join(pacDir, name+".rego")] = "package rules." + name + "\n\n__rego__metadoc__ := " + metadoc +
+			"\n\nresource_type := \"aws_s3_bucket\"\n\ndefault allow = true\n"
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return iacDir, pacDir
+}
+
+func TestNativeEngineRuleFilter(t *testing.T) {
+	iacDir, pacDir := writeFilterFixture(t)
+
+	engine, err := newScanEngine(EngineNative, "", RuleFilter{IncludeFrameworks: []string{"CIS"}, MinSeverity: "High"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := engine.Run(context.Background(), testScanInput(t, iacDir, InputTypeTerraform), pacDir)
+	if err != nil {
+		t.Fatalf("nativeEngine.Run() error = %v", err)
+	}
+	var output RegulaOutput
+	if err := json.Unmarshal(content, &output); err != nil {
+		t.Fatal(err)
+	}
+	if len(output.RuleResults) != 1 || output.RuleResults[0].RuleID != "S3.1" {
+		t.Errorf("nativeEngine.Run() results = %+v, want S3.1 only", output.RuleResults)
+	}
+}
+
+func TestRegulaEngineRuleFilter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake regula executable is a shell script")
+	}
+	iacDir, pacDir := writeFilterFixture(t)
+
+	// The fake regula records its arguments
+	argsFile := filepath.Join(t.TempDir(), "args")
+	executable := filepath.Join(t.TempDir(), "regula")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\necho '{\"rule_results\": []}'\n"
+	if err := os.WriteFile(executable, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := newScanEngine(EngineRegula, executable, RuleFilter{ExcludeRules: []string{"S3.3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.Run(context.Background(), testScanInput(t, iacDir, InputTypeTerraform), pacDir); err != nil {
+		t.Fatalf("regulaEngine.Run() error = %v", err)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	only := []string{}
+	lines := strings.Split(strings.TrimSpace(string(args)), "\n")
+	for i, arg := range lines {
+		if arg == "--only" && i+1 < len(lines) {
+			only = append(only, lines[i+1])
+		}
+	}
+	sort.Strings(only)
+	if want := []string{"s3_logging", "s3_private"}; !reflect.DeepEqual(only, want) {
+		t.Errorf("regula --only = %v, want %v", only, want)
+	}
+
+	// regula is not run when the filter selects no rule
+	os.Remove(argsFile)
+	engine, _ = newScanEngine(EngineRegula, executable, RuleFilter{IncludeRules: []string{"IAM.*"}})
+	content, err := engine.Run(context.Background(), testScanInput(t, iacDir, InputTypeTerraform), pacDir)
+	if err != nil || strings.TrimSpace(string(content)) != `{"rule_results":[]}` {
+		t.Errorf("regulaEngine.Run(no rules) = %s, %v", content, err)
+	}
+	if _, err := os.Stat(argsFile); err == nil {
+		t.Errorf("regulaEngine.Run(no rules) ran regula")
+	}
+}
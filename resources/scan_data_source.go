@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ScanDataSource runs a scan without persisting it as a managed resource.
+type ScanDataSource struct {
+	config *ProviderConfig
+}
+
+// ScanDataSourceModel describes the data source data model. It takes the same
+// inputs as the iac_pac resource, but reports the threshold, min_coverage and
+// fail_on gate through passed and violations instead of failing.
+type ScanDataSourceModel struct {
+	IACPACResourceModel
+
+	Passed     types.Bool `tfsdk:"passed"`
+	Violations types.List `tfsdk:"violations"`
+}
+
+func NewScanDataSource() datasource.DataSource {
+	return &ScanDataSource{}
+}
+
+// gateViolations splits the diagnostics of checkResult into the warnings to
+// surface and the gate failures to report as violations.
+func gateViolations(diags diag.Diagnostics) (diag.Diagnostics, []string) {
+	warnings := diag.Diagnostics{}
+	violations := []string{}
+	for _, d := range diags {
+		if d.Severity() == diag.SeverityError {
+			violations = append(violations, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+			continue
+		}
+		warnings.Append(d)
+	}
+	return warnings, violations
+}
+
+func (d *ScanDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	config, diags := providerConfig(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.config = config
+}
+
+func (d *ScanDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan"
+}
+
+func (d *ScanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ScanDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, diags := config.scanOptions(ctx, d.config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(scanErrorDiagnostics(err)...)
+		return
+	}
+	diags = config.setScanResult(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Engine.IsNull() {
+		config.Engine = types.StringValue(EngineNative)
+	}
+
+	warnings, violations := gateViolations(config.checkResult(ctx, opts, result))
+	resp.Diagnostics.Append(warnings...)
+	config.Passed = types.BoolValue(len(violations) == 0)
+	config.Violations, diags = types.ListValueFrom(ctx, types.StringType, violations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *ScanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataSourceAttributes(scanAttributes())
+	attributes["passed"] = dsschema.BoolAttribute{
+		Description: "Whether the scan meets the threshold, min_coverage and the fail_on gate",
+		Computed:    true,
+	}
+	attributes["violations"] = dsschema.ListAttribute{
+		Description: "Threshold and fail_on gate failures of the scan",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+	resp.Schema = dsschema.Schema{
+		Description: "runs policies against IAC without storing the scan in state. " +
+			"Use passed and violations in check or precondition blocks to gate plans",
+		Attributes: attributes,
+		Blocks:     dataSourceBlocks(scanBlocks()),
+	}
+}
@@ -0,0 +1,89 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestScanDataSourceState(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	(&ScanDataSource{}).Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+	if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("ValidateImplementation() diagnostics = %v", diags)
+	}
+
+	threshold := 100.0
+	opts := ScanOptions{IACPath: "../testdata/valid_iac", PACPath: "../testdata/valid_pac", LogPath: t.TempDir(), Threshold: &threshold}
+	result, err := GetScanResult(ctx, opts)
+	if err != nil {
+		t.Fatalf("GetScanResult() error = %v", err)
+	}
+	model := ScanDataSourceModel{
+		IACPACResourceModel: IACPACResourceModel{
+			IACPath:         types.StringValue("../testdata/valid_iac"),
+			Threshold:       types.Float64Value(100),
+			SeverityWeights: types.MapNull(types.Float64Type),
+			ReportFormats:   types.ListNull(types.StringType),
+
+			IncludeRules:      types.ListNull(types.StringType),
+			ExcludeRules:      types.ListNull(types.StringType),
+			IncludeFrameworks: types.ListNull(types.StringType),
+			Families:          types.ListNull(types.StringType),
+		},
+	}
+	if diags := model.setScanResult(ctx, result); diags.HasError() {
+		t.Fatalf("setScanResult() diagnostics = %v", diags)
+	}
+	_, violations := gateViolations(model.checkResult(ctx, opts, result))
+	model.Passed = types.BoolValue(len(violations) == 0)
+	model.Violations, _ = types.ListValueFrom(ctx, types.StringType, violations)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("State.Set() diagnostics = %v", diags)
+	}
+	var got ScanDataSourceModel
+	if diags := state.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get() diagnostics = %v", diags)
+	}
+	if got.TotalCount.ValueInt64() != result.Score.Total {
+		t.Errorf("total_count = %v, want %d", got.TotalCount, result.Score.Total)
+	}
+	if result.Score.Failed > 0 && (got.Passed.ValueBool() || len(got.Violations.Elements()) == 0) {
+		t.Errorf("passed = %v, violations = %v, want a threshold violation", got.Passed, got.Violations)
+	}
+}
+
+func TestGateViolations(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddWarning("Waiver Expired", "expired")
+	diags.AddError("Security Score Below Threshold", "too low")
+	diags.AddError("Failure Gate Tripped", "critical")
+
+	warnings, violations := gateViolations(diags)
+	if len(warnings) != 1 || warnings[0].Summary() != "Waiver Expired" {
+		t.Errorf("gateViolations() warnings = %v", warnings)
+	}
+	want := []string{"Security Score Below Threshold: too low", "Failure Gate Tripped: critical"}
+	if len(violations) != len(want) {
+		t.Fatalf("gateViolations() violations = %v, want %v", violations, want)
+	}
+	for i := range want {
+		if violations[i] != want[i] {
+			t.Errorf("gateViolations() violations[%d] = %q, want %q", i, violations[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,630 @@
+package resources
+
+import (
+	"fmt"
+	"terraform-provider-starchitect/resources/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scanAttributes returns the attributes shared by the iac_pac resource and the
+// scan data source. They are declared with the resource schema types, the
+// data source converts them with dataSourceAttributes.
+func scanAttributes() map[string]resschema.Attribute {
+	return map[string]resschema.Attribute{
+		"iac_path": resschema.StringAttribute{
+			Description: "IAC path, a Terraform configuration directory or a single .tf or .tf.json file",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("plan_json_path")),
+			},
+		},
+		"plan_json_path": resschema.StringAttribute{
+			Description: "Path to the JSON plan written by terraform show -json, scanned instead of iac_path. " +
+				"Rules are evaluated against the planned values with variables, count, for_each and modules resolved",
+			Optional: true,
+		},
+		"pac_path": resschema.StringAttribute{
+			Description: "PAC path",
+			Optional:    true,
+		},
+		"pac_version": resschema.StringAttribute{
+			Description: "default PAC version, the branch of the PAC repository. Defaults to the provider pac_version",
+			Optional:    true,
+		},
+		"pac_ref": resschema.StringAttribute{
+			Description: "Branch, tag or commit SHA of the PAC repository. Replaces pac_version",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("pac_version")),
+			},
+		},
+		"pac_repository": resschema.StringAttribute{
+			Description: "URL of the PAC repository, e.g. https://git.example.com/rules.git or git@git.example.com:rules.git. " +
+				"Defaults to the provider pac_repository or https://github.com/nonfx/starchitect-cloudguard",
+			Optional: true,
+		},
+		"pac_subdirectory": resschema.StringAttribute{
+			Description: "Directory of the PAC repository holding the aws policies, azurerm and google policies are read from terraform/azure and terraform/gcp. Defaults to the provider pac_subdirectory or terraform/aws",
+			Optional:    true,
+		},
+		"taxonomy_file": resschema.StringAttribute{
+			Description: "YAML or JSON taxonomy merged over the taxonomy of the PAC repository, mapping custom or internal resource types to taxons. " +
+				"Defaults to the provider taxonomy_file",
+			Optional: true,
+		},
+		"log_path": resschema.StringAttribute{
+			Description: "Path to store log files. Defaults to the provider log_path",
+			Optional:    true,
+		},
+		"report_formats": resschema.ListAttribute{
+			Description: "Reports written to log_path: raw (engine JSON), summary, sarif (SARIF 2.1.0), junit (JUnit XML) and html. Defaults to raw and summary",
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.OneOf(ReportFormats...)),
+			},
+		},
+		"engine": resschema.StringAttribute{
+			Description: "Engine evaluating the policies. native (default) evaluates them in-process, " +
+				"regula runs the regula executable found in PATH or at the provider engine_path",
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(EngineNative),
+			Validators: []validator.String{
+				stringvalidator.OneOf(Engines...),
+			},
+		},
+		"threshold": resschema.Float64Attribute{
+			Description: "Minimum required security score (0-100). Defaults to the provider threshold",
+			Optional:    true,
+			Validators: []validator.Float64{
+				float64validator.Between(0, 100),
+			},
+		},
+		"min_coverage": resschema.Float64Attribute{
+			Description: "Minimum share (0-100) of the cloud resources that must be evaluated by at least one rule",
+			Optional:    true,
+			Validators: []validator.Float64{
+				float64validator.Between(0, 100),
+			},
+		},
+		"include_rules": resschema.ListAttribute{
+			Description: "Rule IDs or names to evaluate, as globs such as S3.*. Other rules are skipped before evaluation",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"exclude_rules": resschema.ListAttribute{
+			Description: "Rule IDs or names to skip before evaluation, as globs such as S3.*",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"include_frameworks": resschema.ListAttribute{
+			Description: "Compliance frameworks whose rules are evaluated, matching the rule families by name, e.g. CIS matches CIS-AWS_v1.4.0",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"min_severity": resschema.StringAttribute{
+			Description: "Least severe rules to evaluate, e.g. High evaluates Critical and High rules only. Rules of unknown severity rank like Low",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(Severities...),
+			},
+		},
+		"families": resschema.ListAttribute{
+			Description: "Rule families to evaluate, e.g. CIS-AWS_v1.4.0",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"scan_result": resschema.StringAttribute{
+			Description: "Generated scan result",
+			Computed:    true,
+		},
+		"score": resschema.StringAttribute{
+			Description: "Generated score. evaluated from scan result",
+			Computed:    true,
+		},
+		"score_percent": resschema.Float64Attribute{
+			Description: "Security score in percent. null when no PASS or FAIL results were found",
+			Computed:    true,
+		},
+		"passed_count": resschema.Int64Attribute{
+			Description: "Number of PASS results",
+			Computed:    true,
+		},
+		"failed_count": resschema.Int64Attribute{
+			Description: "Number of FAIL results",
+			Computed:    true,
+		},
+		"waived_count": resschema.Int64Attribute{
+			Description: "Number of WAIVED results",
+			Computed:    true,
+		},
+		"total_count": resschema.Int64Attribute{
+			Description: "Total number of rule results",
+			Computed:    true,
+		},
+		"scoring_mode": resschema.StringAttribute{
+			Description: "Scoring model used to calculate score_percent. " +
+				"flat (default) scores every rule result the same, " +
+				"weighted weighs every result by severity_weights, " +
+				"worst_severity scores by the most severe failing rule (Critical 0, High 25, Medium 50, Low 75, Informational 90, none 100)",
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(ScoringModeFlat),
+			Validators: []validator.String{
+				stringvalidator.OneOf(ScoringModes...),
+			},
+		},
+		"severity_weights": resschema.MapAttribute{
+			Description: "Weight per rule severity used by the weighted scoring mode. " +
+				"Defaults to Critical 10, High 5, Medium 3, Low 1, Informational 0",
+			Optional:    true,
+			ElementType: types.Float64Type,
+			Validators: []validator.Map{
+				mapvalidator.KeysAre(stringvalidator.OneOfCaseInsensitive(append(Severities, severityUnknown)...)),
+				mapvalidator.ValueFloat64sAre(float64validator.AtLeast(0)),
+			},
+		},
+		"severity_breakdown": resschema.MapNestedAttribute{
+			Description: "Result counts and applied weight per rule severity",
+			Computed:    true,
+			NestedObject: resschema.NestedAttributeObject{
+				Attributes: map[string]resschema.Attribute{
+					"passed": resschema.Int64Attribute{
+						Description: "Number of PASS results",
+						Computed:    true,
+					},
+					"failed": resschema.Int64Attribute{
+						Description: "Number of FAIL results",
+						Computed:    true,
+					},
+					"waived": resschema.Int64Attribute{
+						Description: "Number of WAIVED results",
+						Computed:    true,
+					},
+					"weight": resschema.Float64Attribute{
+						Description: "Weight applied to the severity",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"findings": resschema.ListNestedAttribute{
+			Description: "Individual rule evaluations reported by the scan",
+			Computed:    true,
+			NestedObject: resschema.NestedAttributeObject{
+				Attributes: map[string]resschema.Attribute{
+					"rule_id": resschema.StringAttribute{
+						Description: "Rule ID",
+						Computed:    true,
+					},
+					"rule_name": resschema.StringAttribute{
+						Description: "Rule name",
+						Computed:    true,
+					},
+					"rule_summary": resschema.StringAttribute{
+						Description: "Short rule summary",
+						Computed:    true,
+					},
+					"rule_description": resschema.StringAttribute{
+						Description: "Rule description",
+						Computed:    true,
+					},
+					"rule_result": resschema.StringAttribute{
+						Description: "Rule result, PASS, FAIL or WAIVED",
+						Computed:    true,
+					},
+					"rule_raw_result": resschema.BoolAttribute{
+						Description: "Raw boolean result of the rule",
+						Computed:    true,
+					},
+					"rule_severity": resschema.StringAttribute{
+						Description: "Rule severity",
+						Computed:    true,
+					},
+					"resource_id": resschema.StringAttribute{
+						Description: "Evaluated resource ID",
+						Computed:    true,
+					},
+					"resource_type": resschema.StringAttribute{
+						Description: "Evaluated resource type",
+						Computed:    true,
+					},
+					"filepath": resschema.StringAttribute{
+						Description: "File declaring the evaluated resource",
+						Computed:    true,
+					},
+					"start_line": resschema.Int64Attribute{
+						Description: "First line of the evaluated resource block in filepath, 0 when unknown",
+						Computed:    true,
+					},
+					"end_line": resschema.Int64Attribute{
+						Description: "Last line of the evaluated resource block in filepath, 0 when unknown",
+						Computed:    true,
+					},
+					"input_type": resschema.StringAttribute{
+						Description: "Input type of the evaluated file",
+						Computed:    true,
+					},
+					"provider": resschema.StringAttribute{
+						Description: "Provider of the evaluated resource",
+						Computed:    true,
+					},
+					"controls": resschema.ListAttribute{
+						Description: "Compliance controls covered by the rule",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"families": resschema.ListAttribute{
+						Description: "Compliance families covered by the rule",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"message": resschema.StringAttribute{
+						Description: "Rule message",
+						Computed:    true,
+					},
+					"tags": resschema.MapAttribute{
+						Description: "Tags of the evaluated resource",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+		},
+		"coverage": resschema.SingleNestedAttribute{
+			Description: "Cloud resources no rule was evaluated against and why. Resources of other providers, e.g. random or null, are not counted",
+			Computed:    true,
+			Attributes: map[string]resschema.Attribute{
+				"percent": resschema.Float64Attribute{
+					Description: "Share of the cloud resources evaluated by at least one rule. null without cloud resources",
+					Computed:    true,
+				},
+				"resource_count": resschema.Int64Attribute{
+					Description: "Number of cloud resources",
+					Computed:    true,
+				},
+				"covered_count": resschema.Int64Attribute{
+					Description: "Number of cloud resources evaluated by at least one rule",
+					Computed:    true,
+				},
+				"uncovered_resources": resschema.ListAttribute{
+					Description: "Addresses of the cloud resources without rule results",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+				"unmapped_resource_types": resschema.ListAttribute{
+					Description: "Cloud resource types not mapped to a taxon, no policies are fetched for them",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+				"missing_taxons": resschema.ListAttribute{
+					Description: "Taxon directories missing from the PAC repository, as <subdirectory>/<taxon>",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+		"compliance": resschema.MapNestedAttribute{
+			Description: "Status of the controls reported by the rules per compliance framework, e.g. CIS-AWS_v1.4.0",
+			Computed:    true,
+			NestedObject: resschema.NestedAttributeObject{
+				Attributes: map[string]resschema.Attribute{
+					"score": resschema.Float64Attribute{
+						Description: "Share of the passed controls among the passed and failed ones. null when no control was evaluated",
+						Computed:    true,
+					},
+					"passed_controls": resschema.Int64Attribute{
+						Description: "Number of controls of which every evaluated result passed",
+						Computed:    true,
+					},
+					"failed_controls": resschema.Int64Attribute{
+						Description: "Number of controls with at least one FAIL result",
+						Computed:    true,
+					},
+					"not_evaluated_controls": resschema.Int64Attribute{
+						Description: "Number of controls without PASS or FAIL results, e.g. when every failure was waived",
+						Computed:    true,
+					},
+					"controls": resschema.MapNestedAttribute{
+						Description: "Controls of the framework by control ID",
+						Computed:    true,
+						NestedObject: resschema.NestedAttributeObject{
+							Attributes: map[string]resschema.Attribute{
+								"status": resschema.StringAttribute{
+									Description: "PASS, FAIL or NOT_EVALUATED",
+									Computed:    true,
+								},
+								"passed": resschema.Int64Attribute{
+									Description: "Number of PASS results",
+									Computed:    true,
+								},
+								"failed": resschema.Int64Attribute{
+									Description: "Number of FAIL results",
+									Computed:    true,
+								},
+								"waived": resschema.Int64Attribute{
+									Description: "Number of WAIVED results",
+									Computed:    true,
+								},
+								"rule_ids": resschema.ListAttribute{
+									Description: "Rules reporting the control",
+									Computed:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// scanBlocks returns the blocks shared by the iac_pac resource and the scan data source.
+func scanBlocks() map[string]resschema.Block {
+	return map[string]resschema.Block{
+		"fail_on": resschema.SingleNestedBlock{
+			Description: "Gate failing when the scan has more FAIL results than allowed per severity, " +
+				"or when any of the listed rules fails. Evaluated alongside threshold: the iac_pac resource fails the plan, " +
+				"the scan data source reports the failure in passed and violations",
+			Attributes: map[string]resschema.Attribute{
+				"critical": resschema.Int64Attribute{
+					Description: "Maximum allowed Critical failures",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"high": resschema.Int64Attribute{
+					Description: "Maximum allowed High failures",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"medium": resschema.Int64Attribute{
+					Description: "Maximum allowed Medium failures",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"low": resschema.Int64Attribute{
+					Description: "Maximum allowed Low failures",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"informational": resschema.Int64Attribute{
+					Description: "Maximum allowed Informational failures",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.AtLeast(0)},
+				},
+				"rule_ids": resschema.ListAttribute{
+					Description: "Rule IDs of which any failure fails the gate",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+		"pac_auth": resschema.SingleNestedBlock{
+			Description: "Credentials of a private PAC repository. Defaults to the provider pac_auth",
+			Attributes: map[string]resschema.Attribute{
+				"username": resschema.StringAttribute{
+					Description: "Username, git when unset",
+					Optional:    true,
+				},
+				"token": resschema.StringAttribute{
+					Description: "Access token of HTTP(S) repositories",
+					Optional:    true,
+					Sensitive:   true,
+				},
+				"ssh_private_key": resschema.StringAttribute{
+					Description: "PEM encoded SSH private key of SSH repositories",
+					Optional:    true,
+					Sensitive:   true,
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("ssh_private_key_file")),
+					},
+				},
+				"ssh_private_key_file": resschema.StringAttribute{
+					Description: "Path to the SSH private key of SSH repositories",
+					Optional:    true,
+				},
+				"ssh_key_passphrase": resschema.StringAttribute{
+					Description: "Passphrase of the SSH private key",
+					Optional:    true,
+					Sensitive:   true,
+				},
+			},
+		},
+		"pac_verification": resschema.SingleNestedBlock{
+			Description: "Verifies the fetched policies before they are used and fails the scan when the pack has been tampered with. " +
+				"Defaults to the provider pac_verification",
+			Attributes: map[string]resschema.Attribute{
+				"commit": resschema.StringAttribute{
+					Description: "Commit SHA the policies are pinned to. Fetched when neither pac_ref nor pac_version is set",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(utils.CommitSHARegex, "must be a full or abbreviated commit SHA"),
+					},
+				},
+				"checksum_file": resschema.StringAttribute{
+					Description: "sha256sum style manifest of every .rego file, relative to the root of the PAC repository or absolute",
+					Optional:    true,
+				},
+				"public_key": resschema.StringAttribute{
+					Description: "PEM encoded ed25519 public key the checksum_file must be signed with",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("checksum_file")),
+					},
+				},
+				"signature_file": resschema.StringAttribute{
+					Description: "Base64 encoded ed25519 signature of the checksum_file, checksum_file with a .sig suffix when unset",
+					Optional:    true,
+				},
+			},
+		},
+		"waiver": resschema.ListNestedBlock{
+			Description: "Accepts the risk of failing findings. Matching FAIL results are reported as WAIVED " +
+				"and excluded from the score and the fail_on gate",
+			NestedObject: resschema.NestedBlockObject{
+				Attributes: map[string]resschema.Attribute{
+					"rule_id": resschema.StringAttribute{
+						Description: "Waived rule ID",
+						Required:    true,
+					},
+					"resource_id": resschema.StringAttribute{
+						Description: "Glob of the waived resource IDs, e.g. module.vpc.aws_security_group.*. Defaults to every resource",
+						Optional:    true,
+					},
+					"reason": resschema.StringAttribute{
+						Description: "Why the risk is accepted",
+						Required:    true,
+					},
+					"expires": resschema.StringAttribute{
+						Description: "Date (YYYY-MM-DD, valid through that day in UTC) or RFC 3339 timestamp after which the waiver no longer applies",
+						Optional:    true,
+					},
+					"owner": resschema.StringAttribute{
+						Description: "Owner of the accepted risk",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceAttributes adapts the scan attributes to the iac_pac resource, the
+// computed scan results keep their state until the scan runs again.
+func resourceAttributes(attributes map[string]resschema.Attribute) map[string]resschema.Attribute {
+	for name, attribute := range attributes {
+		if !attribute.IsComputed() || attribute.IsOptional() {
+			continue
+		}
+		switch a := attribute.(type) {
+		case resschema.StringAttribute:
+			a.PlanModifiers = []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case resschema.Float64Attribute:
+			a.PlanModifiers = []planmodifier.Float64{float64planmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case resschema.Int64Attribute:
+			a.PlanModifiers = []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case resschema.ListNestedAttribute:
+			a.PlanModifiers = []planmodifier.List{listplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case resschema.MapNestedAttribute:
+			a.PlanModifiers = []planmodifier.Map{mapplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		case resschema.SingleNestedAttribute:
+			a.PlanModifiers = []planmodifier.Object{objectplanmodifier.UseStateForUnknown()}
+			attributes[name] = a
+		default:
+			panic(fmt.Sprintf("no plan modifier for computed attribute %s of type %T", name, attribute))
+		}
+	}
+	return attributes
+}
+
+// dataSourceAttributes converts the scan attributes to the data source schema
+// types. Defaults are dropped, data sources fill in computed values on read.
+func dataSourceAttributes(attributes map[string]resschema.Attribute) map[string]dsschema.Attribute {
+	converted := make(map[string]dsschema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		converted[name] = dataSourceAttribute(attribute)
+	}
+	return converted
+}
+
+func dataSourceAttribute(attribute resschema.Attribute) dsschema.Attribute {
+	switch a := attribute.(type) {
+	case resschema.StringAttribute:
+		return dsschema.StringAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, Validators: a.Validators}
+	case resschema.BoolAttribute:
+		return dsschema.BoolAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, Validators: a.Validators}
+	case resschema.Float64Attribute:
+		return dsschema.Float64Attribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, Validators: a.Validators}
+	case resschema.Int64Attribute:
+		return dsschema.Int64Attribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, Validators: a.Validators}
+	case resschema.ListAttribute:
+		return dsschema.ListAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, ElementType: a.ElementType, Validators: a.Validators}
+	case resschema.MapAttribute:
+		return dsschema.MapAttribute{Description: a.Description, Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive, ElementType: a.ElementType, Validators: a.Validators}
+	case resschema.ListNestedAttribute:
+		return dsschema.ListNestedAttribute{
+			Description:  a.Description,
+			Required:     a.Required,
+			Optional:     a.Optional,
+			Computed:     a.Computed,
+			Sensitive:    a.Sensitive,
+			NestedObject: dsschema.NestedAttributeObject{Attributes: dataSourceAttributes(a.NestedObject.Attributes)},
+			Validators:   a.Validators,
+		}
+	case resschema.MapNestedAttribute:
+		return dsschema.MapNestedAttribute{
+			Description:  a.Description,
+			Required:     a.Required,
+			Optional:     a.Optional,
+			Computed:     a.Computed,
+			Sensitive:    a.Sensitive,
+			NestedObject: dsschema.NestedAttributeObject{Attributes: dataSourceAttributes(a.NestedObject.Attributes)},
+			Validators:   a.Validators,
+		}
+	case resschema.SingleNestedAttribute:
+		return dsschema.SingleNestedAttribute{
+			Description: a.Description,
+			Required:    a.Required,
+			Optional:    a.Optional,
+			Computed:    a.Computed,
+			Sensitive:   a.Sensitive,
+			Attributes:  dataSourceAttributes(a.Attributes),
+			Validators:  a.Validators,
+		}
+	}
+	panic(fmt.Sprintf("no data source attribute for %T", attribute))
+}
+
+// dataSourceBlocks converts the scan blocks to the data source schema types.
+func dataSourceBlocks(blocks map[string]resschema.Block) map[string]dsschema.Block {
+	converted := make(map[string]dsschema.Block, len(blocks))
+	for name, block := range blocks {
+		switch b := block.(type) {
+		case resschema.SingleNestedBlock:
+			converted[name] = dsschema.SingleNestedBlock{
+				Description: b.Description,
+				Attributes:  dataSourceAttributes(b.Attributes),
+				Blocks:      dataSourceBlocks(b.Blocks),
+				Validators:  b.Validators,
+			}
+		case resschema.ListNestedBlock:
+			converted[name] = dsschema.ListNestedBlock{
+				Description: b.Description,
+				NestedObject: dsschema.NestedBlockObject{
+					Attributes: dataSourceAttributes(b.NestedObject.Attributes),
+					Blocks:     dataSourceBlocks(b.NestedObject.Blocks),
+				},
+				Validators: b.Validators,
+			}
+		default:
+			panic(fmt.Sprintf("no data source block for %T", block))
+		}
+	}
+	return converted
+}
@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestScanSchemas(t *testing.T) {
+	ctx := context.Background()
+	resourceResp := &resource.SchemaResponse{}
+	(&IACPACResource{}).Schema(ctx, resource.SchemaRequest{}, resourceResp)
+	if diags := resourceResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("resource ValidateImplementation() diagnostics = %v", diags)
+	}
+	dataSourceResp := &datasource.SchemaResponse{}
+	(&ScanDataSource{}).Schema(ctx, datasource.SchemaRequest{}, dataSourceResp)
+	if diags := dataSourceResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("data source ValidateImplementation() diagnostics = %v", diags)
+	}
+
+	for name := range resourceResp.Schema.Attributes {
+		if _, ok := dataSourceResp.Schema.Attributes[name]; !ok {
+			t.Errorf("data source is missing attribute %s", name)
+		}
+	}
+	for name := range dataSourceResp.Schema.Attributes {
+		if _, ok := resourceResp.Schema.Attributes[name]; !ok && name != "passed" && name != "violations" {
+			t.Errorf("data source has attribute %s the resource does not have", name)
+		}
+	}
+	for name := range resourceResp.Schema.Blocks {
+		if _, ok := dataSourceResp.Schema.Blocks[name]; !ok {
+			t.Errorf("data source is missing block %s", name)
+		}
+	}
+
+	findings := resourceResp.Schema.Attributes["findings"].(resschema.ListNestedAttribute)
+	if len(findings.PlanModifiers) == 0 {
+		t.Errorf("resource findings has no plan modifiers, want UseStateForUnknown")
+	}
+	engine := resourceResp.Schema.Attributes["engine"].(resschema.StringAttribute)
+	if engine.Default == nil || len(engine.PlanModifiers) != 0 {
+		t.Errorf("resource engine = %+v, want a default and no plan modifiers", engine)
+	}
+}
@@ -0,0 +1,207 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-starchitect/resources/utils"
+)
+
+const (
+	// ScoringModeFlat scores every PASS and FAIL result the same.
+	ScoringModeFlat = "flat"
+	// ScoringModeWeighted weighs every result by the weight of its severity.
+	ScoringModeWeighted = "weighted"
+	// ScoringModeWorstSeverity scores by the most severe failing rule only.
+	ScoringModeWorstSeverity = "worst_severity"
+)
+
+// ScoringModes lists the supported values of the scoring_mode attribute.
+var ScoringModes = []string{ScoringModeFlat, ScoringModeWeighted, ScoringModeWorstSeverity}
+
+// Severities lists the rule severities known to the scoring model, most severe first.
+var Severities = []string{"Critical", "High", "Medium", "Low", "Informational"}
+
+// severityUnknown is used for results without a recognised severity.
+const severityUnknown = "Unknown"
+
+// defaultSeverityWeights are used by the weighted mode for severities
+// missing from the configured severity_weights.
+var defaultSeverityWeights = map[string]float64{
+	"Critical":      10,
+	"High":          5,
+	"Medium":        3,
+	"Low":           1,
+	"Informational": 0,
+	severityUnknown: 1,
+}
+
+// worstSeverityScores is the score of the worst_severity mode when the most
+// severe failing rule has the given severity.
+var worstSeverityScores = map[string]float64{
+	"Critical":      0,
+	"High":          25,
+	"Medium":        50,
+	"Low":           75,
+	"Informational": 90,
+	severityUnknown: 75,
+}
+
+// ScoringModel selects how rule results are turned into a score.
+type ScoringModel struct {
+	Mode string
+	// Weights overrides defaultSeverityWeights, keyed by normalized severity,
+	// see normalizeSeverityWeights.
+	Weights map[string]float64
+}
+
+// weight returns the weight of a normalized severity.
+func (m ScoringModel) weight(severity string) float64 {
+	if weight, ok := m.Weights[severity]; ok {
+		return weight
+	}
+	return defaultSeverityWeights[severity]
+}
+
+// normalizeSeverityWeights keys the configured weights by normalized
+// severity. Keys naming the same severity, e.g. High and high, are rejected.
+func normalizeSeverityWeights(weights map[string]float64) (map[string]float64, error) {
+	normalized := make(map[string]float64, len(weights))
+	keys := map[string]string{}
+	for _, key := range utils.SortedKeys(weights) {
+		severity := normalizeSeverity(key)
+		if previous, ok := keys[severity]; ok {
+			return nil, fmt.Errorf("%q and %q both set the weight of the %s severity", previous, key, severity)
+		}
+		keys[severity] = key
+		normalized[severity] = weights[key]
+	}
+	return normalized, nil
+}
+
+// SeverityScore holds the result counts of a single severity.
+type SeverityScore struct {
+	Passed int64
+	Failed int64
+	Waived int64
+	// Weight is the weight the scoring model applied to the severity.
+	Weight float64
+}
+
+// ScoreSummary holds the result counts of a scan and the score derived from them.
+type ScoreSummary struct {
+	Passed int64
+	Failed int64
+	Waived int64
+	Total  int64
+	// Mode is the scoring mode used to calculate Percent.
+	Mode string
+	// Percent is the score calculated by the scoring model.
+	// It is only meaningful when Evaluated returns true.
+	Percent float64
+	// Severities breaks the counts down by normalized severity.
+	Severities map[string]SeverityScore
+}
+
+// Evaluated reports whether any PASS or FAIL result contributed to the score.
+func (s ScoreSummary) Evaluated() bool {
+	return s.Passed+s.Failed > 0
+}
+
+// String renders the summary in the format of the score attribute.
+func (s ScoreSummary) String() string {
+	if !s.Evaluated() {
+		return "no PASS or FAIL results found"
+	}
+	return fmt.Sprintf("PASSED: %d FAILED: %d Score: %.2f percent", s.Passed, s.Failed, s.Percent)
+}
+
+// normalizeSeverity maps a rule severity onto one of Severities, case
+// insensitively, or severityUnknown.
+func normalizeSeverity(severity string) string {
+	for _, known := range Severities {
+		if strings.EqualFold(strings.TrimSpace(severity), known) {
+			return known
+		}
+	}
+	return severityUnknown
+}
+
+// severityRank orders normalized severities, lower is more severe.
+func severityRank(severity string) int {
+	for i, known := range Severities {
+		if known == severity {
+			return i
+		}
+	}
+	// Unknown severities rank like Low
+	return severityRank("Low")
+}
+
+func calculateScore(regulaOutput RegulaOutput, model ScoringModel) ScoreSummary {
+	summary := ScoreSummary{
+		Mode:       model.Mode,
+		Severities: map[string]SeverityScore{},
+	}
+	if summary.Mode == "" {
+		summary.Mode = ScoringModeFlat
+	}
+	for _, severity := range Severities {
+		summary.Severities[severity] = SeverityScore{Weight: model.weight(severity)}
+	}
+
+	for _, rule := range regulaOutput.RuleResults {
+		severity := normalizeSeverity(rule.RuleSeverity)
+		bucket, ok := summary.Severities[severity]
+		if !ok {
+			bucket.Weight = model.weight(severity)
+		}
+		switch rule.RuleResult {
+		case "PASS":
+			summary.Passed++
+			bucket.Passed++
+		case "FAIL":
+			summary.Failed++
+			bucket.Failed++
+		case "WAIVED":
+			summary.Waived++
+			bucket.Waived++
+		}
+		summary.Severities[severity] = bucket
+	}
+	summary.Total = int64(len(regulaOutput.RuleResults))
+
+	if !summary.Evaluated() {
+		return summary
+	}
+
+	switch summary.Mode {
+	case ScoringModeWeighted:
+		var passedWeight, totalWeight float64
+		for _, bucket := range summary.Severities {
+			passedWeight += float64(bucket.Passed) * bucket.Weight
+			totalWeight += float64(bucket.Passed+bucket.Failed) * bucket.Weight
+		}
+		summary.Percent = 100
+		if totalWeight > 0 {
+			summary.Percent = (passedWeight / totalWeight) * 100
+		}
+	case ScoringModeWorstSeverity:
+		summary.Percent = 100
+		failing := []string{}
+		for severity, bucket := range summary.Severities {
+			if bucket.Failed > 0 {
+				failing = append(failing, severity)
+			}
+		}
+		if len(failing) > 0 {
+			sort.Slice(failing, func(i, j int) bool {
+				return severityRank(failing[i]) < severityRank(failing[j])
+			})
+			summary.Percent = worstSeverityScores[failing[0]]
+		}
+	default:
+		summary.Percent = (float64(summary.Passed) / float64(summary.Passed+summary.Failed)) * 100
+	}
+	return summary
+}
@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateScore(t *testing.T) {
+	mixed := []RegulaRuleResult{
+		{RuleResult: "PASS", RuleSeverity: "Low"},
+		{RuleResult: "PASS", RuleSeverity: "Low"},
+		{RuleResult: "PASS", RuleSeverity: "Low"},
+		{RuleResult: "FAIL", RuleSeverity: "Critical"},
+		{RuleResult: "WAIVED", RuleSeverity: "high"},
+	}
+
+	tests := []struct {
+		name        string
+		results     []RegulaRuleResult
+		model       ScoringModel
+		wantPercent float64
+		wantString  string
+	}{
+		{
+			name:        "flat",
+			results:     mixed,
+			wantPercent: 75,
+			wantString:  "PASSED: 3 FAILED: 1 Score: 75.00 percent",
+		},
+		{
+			name:        "weighted with default weights",
+			results:     mixed,
+			model:       ScoringModel{Mode: ScoringModeWeighted},
+			wantPercent: 3.0 / 13.0 * 100,
+			wantString:  "PASSED: 3 FAILED: 1 Score: 23.08 percent",
+		},
+		{
+			name:        "weighted with configured weights",
+			results:     mixed,
+			model:       ScoringModel{Mode: ScoringModeWeighted, Weights: map[string]float64{"Critical": 3, "Low": 1}},
+			wantPercent: 50,
+			wantString:  "PASSED: 3 FAILED: 1 Score: 50.00 percent",
+		},
+		{
+			name:        "worst severity",
+			results:     mixed,
+			model:       ScoringModel{Mode: ScoringModeWorstSeverity},
+			wantPercent: 0,
+			wantString:  "PASSED: 3 FAILED: 1 Score: 0.00 percent",
+		},
+		{
+			name:        "worst severity without failures",
+			results:     mixed[:3],
+			model:       ScoringModel{Mode: ScoringModeWorstSeverity},
+			wantPercent: 100,
+			wantString:  "PASSED: 3 FAILED: 0 Score: 100.00 percent",
+		},
+		{
+			name:       "no results",
+			results:    nil,
+			wantString: "no PASS or FAIL results found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateScore(RegulaOutput{RuleResults: tt.results}, tt.model)
+			if got.Percent != tt.wantPercent {
+				t.Errorf("calculateScore() percent = %v, want %v", got.Percent, tt.wantPercent)
+			}
+			if got.String() != tt.wantString {
+				t.Errorf("ScoreSummary.String() = %q, want %q", got.String(), tt.wantString)
+			}
+			if got.Total != int64(len(tt.results)) {
+				t.Errorf("calculateScore() total = %d, want %d", got.Total, len(tt.results))
+			}
+		})
+	}
+}
+
+func TestCalculateScoreSeverities(t *testing.T) {
+	got := calculateScore(RegulaOutput{RuleResults: []RegulaRuleResult{
+		{RuleResult: "FAIL", RuleSeverity: "HIGH"},
+		{RuleResult: "WAIVED", RuleSeverity: "High"},
+		{RuleResult: "PASS", RuleSeverity: ""},
+	}}, ScoringModel{})
+
+	if got.Mode != ScoringModeFlat {
+		t.Errorf("calculateScore() mode = %q, want %q", got.Mode, ScoringModeFlat)
+	}
+	if want := (SeverityScore{Failed: 1, Waived: 1, Weight: 5}); got.Severities["High"] != want {
+		t.Errorf("calculateScore() High = %+v, want %+v", got.Severities["High"], want)
+	}
+	if want := (SeverityScore{Passed: 1, Weight: 1}); got.Severities[severityUnknown] != want {
+		t.Errorf("calculateScore() Unknown = %+v, want %+v", got.Severities[severityUnknown], want)
+	}
+	if _, ok := got.Severities["Informational"]; !ok {
+		t.Errorf("calculateScore() is missing the Informational severity")
+	}
+}
+
+func TestNormalizeSeverityWeights(t *testing.T) {
+	got, err := normalizeSeverityWeights(map[string]float64{"critical": 3, " LOW ": 1, "unknown": 2})
+	if err != nil {
+		t.Fatalf("normalizeSeverityWeights() error = %v", err)
+	}
+	want := map[string]float64{"Critical": 3, "Low": 1, severityUnknown: 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeSeverityWeights() = %v, want %v", got, want)
+	}
+
+	if _, err := normalizeSeverityWeights(map[string]float64{"High": 5, "high": 1}); err == nil {
+		t.Errorf("normalizeSeverityWeights(High, high) error = nil, want a duplicate error")
+	}
+}
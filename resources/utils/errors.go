@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// SourceProblem is a problem found at a location of a source file.
+type SourceProblem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p SourceProblem) String() string {
+	switch {
+	case p.File == "":
+		return p.Message
+	case p.Line == 0:
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+func joinProblems(problems []SourceProblem) string {
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// IaCParseError is returned when the Terraform configuration cannot be parsed.
+type IaCParseError struct {
+	Problems []SourceProblem
+	// Plan is set when the IaC is a plan JSON file rather than a configuration directory.
+	Plan bool
+}
+
+func (e *IaCParseError) Error() string {
+	return fmt.Sprintf("failed to parse IaC: %s", joinProblems(e.Problems))
+}
+
+// newIaCParseError converts the error diagnostics of the HCL parser.
+func newIaCParseError(diags hcl.Diagnostics) *IaCParseError {
+	parseErr := &IaCParseError{}
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		problem := SourceProblem{Message: diag.Summary}
+		if diag.Detail != "" {
+			problem.Message = fmt.Sprintf("%s. %s", diag.Summary, diag.Detail)
+		}
+		if diag.Subject != nil {
+			problem.File = diag.Subject.Filename
+			problem.Line = diag.Subject.Start.Line
+			problem.Column = diag.Subject.Start.Column
+		}
+		parseErr.Problems = append(parseErr.Problems, problem)
+	}
+	return parseErr
+}
+
+// PACFetchError is returned when the policies cannot be fetched or prepared.
+type PACFetchError struct {
+	// Source is the repository or path the policies were fetched from.
+	Source string
+	Err    error
+}
+
+func (e *PACFetchError) Error() string {
+	return fmt.Sprintf("failed to fetch policies from %s: %v", e.Source, e.Err)
+}
+
+func (e *PACFetchError) Unwrap() error {
+	return e.Err
+}
@@ -1,55 +1,290 @@
 package utils
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"io/fs"
+	"log"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 )
 
-func extractRegoFiles(tempPACPath string, taxons []string) (string, error) {
-	// Create a directory to store all .rego files
-	outputDir := filepath.Join(tempPACPath, "rego_files")
-	err := os.MkdirAll(outputDir, os.ModePerm)
+// engineLibraries are packages provided by the scan engines. They are never
+// extracted from the PAC repository.
+var engineLibraries = []string{"data.fugue"}
+
+var (
+	regoPackageRegex = regexp.MustCompile(`^\s*package\s+([\w.]+)`)
+	regoImportRegex  = regexp.MustCompile(`^\s*import\s+(data\.[\w.]+)`)
+)
+
+// regoFile is the package declared and the data packages imported by a .rego file.
+type regoFile struct {
+	pkg     string
+	imports []string
+}
+
+// readRegoFile reads the package and imports of a .rego file. Files that do
+// not parse are reported by the engine once they are compiled.
+func readRegoFile(file string) (regoFile, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return regoFile{}, err
+	}
+	defer f.Close()
+
+	parsed := regoFile{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := regoPackageRegex.FindStringSubmatch(line); match != nil && parsed.pkg == "" {
+			parsed.pkg = "data." + match[1]
+		} else if match := regoImportRegex.FindStringSubmatch(line); match != nil {
+			parsed.imports = append(parsed.imports, match[1])
+		}
+	}
+	return parsed, scanner.Err()
+}
+
+// packageImports reports whether importing ref imports the package pkg, i.e.
+// one is a dotted prefix of the other.
+func packageImports(ref, pkg string) bool {
+	return ref == pkg || strings.HasPrefix(ref, pkg+".") || strings.HasPrefix(pkg, ref+".")
+}
+
+// PolicyCollision is a package declared by rules of several directories,
+// which the engines would silently merge into one rule.
+type PolicyCollision struct {
+	Package string
+	// Files are the colliding files relative to the root of the PAC repository.
+	Files []string
+}
+
+// PolicyCollisionError is returned when the extracted policies collide.
+type PolicyCollisionError struct {
+	Collisions []PolicyCollision
+}
+
+func (e *PolicyCollisionError) Error() string {
+	messages := make([]string, 0, len(e.Collisions))
+	for _, collision := range e.Collisions {
+		messages = append(messages, collision.String())
+	}
+	return fmt.Sprintf("colliding policies: %s", strings.Join(messages, "; "))
+}
+
+func (c PolicyCollision) String() string {
+	return fmt.Sprintf("package %s is declared by %s", strings.TrimPrefix(c.Package, "data."), strings.Join(c.Files, ", "))
+}
+
+// regoExtraction copies policies of a checkout into outputDir, keeping their
+// path relative to the root of the checkout so that files of the same name in
+// different taxons and the layout of libraries are preserved. The checkout is
+// shared and never written to.
+type regoExtraction struct {
+	repoPath  string
+	outputDir string
+	// files are the extracted files by slash separated path relative to repoPath
+	files map[string]regoFile
+	// libraries indexes the .rego files of the checkout, built when first needed
+	libraries map[string]regoFile
+}
+
+func newRegoExtraction(repoPath, outputDir string) (*regoExtraction, error) {
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+	return &regoExtraction{repoPath: repoPath, outputDir: outputDir, files: map[string]regoFile{}}, nil
+}
+
+// extract copies a .rego file of the checkout, given relative to its root.
+func (e *regoExtraction) extract(rel string) error {
+	if _, ok := e.files[rel]; ok {
+		return nil
+	}
+	source := filepath.Join(e.repoPath, filepath.FromSlash(rel))
+	parsed, err := readRegoFile(source)
 	if err != nil {
-		return "", fmt.Errorf("failed to create output directory: %v", err)
+		return fmt.Errorf("failed to read file %s: %v", rel, err)
+	}
+	destPath := filepath.Join(e.outputDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return err
 	}
+	if err := copyFile(source, destPath); err != nil {
+		return fmt.Errorf("failed to copy file %s: %v", rel, err)
+	}
+	e.files[rel] = parsed
+	return nil
+}
 
-	// Iterate over each taxon
+// extractTaxons copies the .rego files of the taxons found in folder and
+// returns the taxons without a directory.
+func (e *regoExtraction) extractTaxons(folder string, taxons []string) ([]string, error) {
+	missing := []string{}
 	for _, taxon := range taxons {
 		// Use filepath.Join to handle spaces and other path issues
-		taxonPath := filepath.Join(tempPACPath, taxon)
+		taxonPath := filepath.Join(folder, taxon)
 
 		// Check if the taxon directory exists
 		if _, err := os.Stat(taxonPath); os.IsNotExist(err) {
-			fmt.Printf("Directory %s does not exist, skipping...\n", taxonPath)
+			missing = append(missing, taxon)
 			continue
 		}
 
-		// Walk through the directory
-		err := filepath.Walk(taxonPath, func(path string, info os.FileInfo, err error) error {
+		err := filepath.WalkDir(taxonPath, func(file string, entry fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
+			if entry.IsDir() || filepath.Ext(file) != ".rego" {
+				return nil
+			}
+			rel, err := filepath.Rel(e.repoPath, file)
+			if err != nil {
+				return err
+			}
+			return e.extract(filepath.ToSlash(rel))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error walking the path %s: %v", taxonPath, err)
+		}
+	}
+	return missing, nil
+}
 
-			// Check if the file has a .rego extension
-			if !info.IsDir() && filepath.Ext(path) == ".rego" {
-				// Copy the .rego file to the output directory
-				destPath := filepath.Join(outputDir, info.Name())
-				err := copyFile(path, destPath)
-				if err != nil {
-					return fmt.Errorf("failed to copy file %s: %v", path, err)
+// indexLibraries reads the package of every .rego file of the checkout.
+// Files and directories that cannot be read are skipped with a warning, they
+// only matter when an extracted rule imports them, which the engine reports.
+func (e *regoExtraction) indexLibraries() error {
+	e.libraries = map[string]regoFile{}
+	return filepath.WalkDir(e.repoPath, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if file == e.repoPath {
+				return err
+			}
+			log.Printf("Warning: skipping %s while indexing the policy libraries: %v", file, err)
+			return nil
+		}
+		if entry.IsDir() {
+			if file != e.repoPath && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(file) != ".rego" || strings.HasSuffix(file, "_test.rego") {
+			return nil
+		}
+		parsed, err := readRegoFile(file)
+		if err != nil {
+			log.Printf("Warning: skipping %s while indexing the policy libraries: %v", file, err)
+			return nil
+		}
+		rel, err := filepath.Rel(e.repoPath, file)
+		if err != nil {
+			return err
+		}
+		e.libraries[filepath.ToSlash(rel)] = parsed
+		return nil
+	})
+}
+
+// extractLibraries copies the packages imported by the extracted rules, and
+// the packages those import, from anywhere in the checkout.
+func (e *regoExtraction) extractLibraries() error {
+	resolved := map[string]bool{}
+	for _, library := range engineLibraries {
+		resolved[library] = true
+	}
+
+	for {
+		pending := []string{}
+		for rel, parsed := range e.files {
+			// Tests are not evaluated, their fixtures are left out
+			if strings.HasSuffix(rel, "_test.rego") {
+				continue
+			}
+			for _, ref := range parsed.imports {
+				if !resolved[ref] {
+					resolved[ref] = true
+					pending = append(pending, ref)
 				}
 			}
+		}
+		if len(pending) == 0 {
 			return nil
-		})
+		}
+		if e.libraries == nil {
+			if err := e.indexLibraries(); err != nil {
+				return fmt.Errorf("failed to index the libraries: %v", err)
+			}
+		}
+		for _, ref := range pending {
+			if isEngineLibrary(ref) {
+				continue
+			}
+			for _, rel := range SortedKeys(e.libraries) {
+				if packageImports(ref, e.libraries[rel].pkg) {
+					if err := e.extract(rel); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
 
-		if err != nil {
-			return "", fmt.Errorf("error walking the path %s: %v", taxonPath, err)
+// sourcePaths returns the paths of the extracted files in the checkout.
+func (e *regoExtraction) sourcePaths() []string {
+	paths := make([]string, 0, len(e.files))
+	for _, rel := range SortedKeys(e.files) {
+		paths = append(paths, filepath.Join(e.repoPath, filepath.FromSlash(rel)))
+	}
+	return paths
+}
+
+func isEngineLibrary(ref string) bool {
+	for _, library := range engineLibraries {
+		if packageImports(ref, library) {
+			return true
 		}
 	}
+	return false
+}
+
+// checkCollisions reports the packages declared by extracted files of several
+// directories. Files of a single directory may share a package, e.g. a
+// library split into several files. Files left in the checkout never collide.
+func (e *regoExtraction) checkCollisions() error {
+	dirs := map[string]map[string]bool{}
+	files := map[string][]string{}
+	for rel, parsed := range e.files {
+		if parsed.pkg == "" || strings.HasSuffix(rel, "_test.rego") {
+			continue
+		}
+		if dirs[parsed.pkg] == nil {
+			dirs[parsed.pkg] = map[string]bool{}
+		}
+		dirs[parsed.pkg][path.Dir(rel)] = true
+		files[parsed.pkg] = append(files[parsed.pkg], rel)
+	}
 
-	return outputDir, nil
+	collisionErr := &PolicyCollisionError{}
+	for _, pkg := range SortedKeys(files) {
+		if len(dirs[pkg]) < 2 {
+			continue
+		}
+		sort.Strings(files[pkg])
+		collisionErr.Collisions = append(collisionErr.Collisions, PolicyCollision{Package: pkg, Files: files[pkg]})
+	}
+	if len(collisionErr.Collisions) > 0 {
+		return collisionErr
+	}
+	return nil
 }
 
 func copyFile(src, dst string) error {
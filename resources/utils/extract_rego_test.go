@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// extractedFiles lists the files below dir as slash separated relative paths.
+func extractedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files := []string{}
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestRegoExtraction(t *testing.T) {
+	repoPath := t.TempDir()
+	writeFiles(t, repoPath, map[string]string{
+		"terraform/aws/S3/logging_v1.rego": "package rules.s3_logging_v1\n\nimport data.fugue\nimport data.lib.tags\n",
+		"terraform/aws/S3/lib/s3.rego":     "package lib.s3\n",
+		"terraform/aws/EC2/logging_v1.rego": "package rules.ec2_logging_v1\n\nimport data.lib.tags.required as required\n" +
+			"import future.keywords.in\n",
+		"terraform/aws/EC2/logging_v1_test.rego": "package rules.ec2_logging_v1\n\nimport data.testing.fixtures\n",
+		"terraform/aws/IAM/iam_v1.rego":          "package rules.iam_v1\n",
+		"lib/tags/tags.rego":                     "package lib.tags\n\nimport data.lib.naming\n",
+		"lib/tags/required.rego":                 "package lib.tags.required\n",
+		"lib/naming.rego":                        "package lib.naming\n",
+		"lib/unused.rego":                        "package lib.unused\n",
+		"lib/legacy/unused.rego":                 "package lib.unused\n",
+		"terraform/aws/IAM Legacy/iam_v1.rego":   "package rules.iam_v1\n",
+		"lib/generated.rego":                     "package lib.generated\n\n# " + strings.Repeat("x", 100000) + "\n",
+		"lib/fugue.rego":                         "package fugue\n",
+		"testing/fixtures.rego":                  "package testing.fixtures\n",
+		"README.md":                              "rules\n",
+	})
+	outputDir := filepath.Join(t.TempDir(), "rego_files")
+
+	extraction, err := newRegoExtraction(repoPath, outputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing, err := extraction.extractTaxons(filepath.Join(repoPath, "terraform/aws"), []string{"S3", "EC2", "KMS"})
+	if err != nil {
+		t.Fatalf("extractTaxons() error = %v", err)
+	}
+	if want := []string{"KMS"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("extractTaxons() missing = %v, want %v", missing, want)
+	}
+	if err := extraction.extractLibraries(); err != nil {
+		t.Fatalf("extractLibraries() error = %v", err)
+	}
+	// Packages declared twice by files left in the checkout do not collide
+	if err := extraction.checkCollisions(); err != nil {
+		t.Errorf("checkCollisions() error = %v", err)
+	}
+
+	// Rules of the same name keep apart in their taxon and the imported
+	// libraries keep their layout. The engine provided fugue library and the
+	// fixtures of tests are not extracted, and the library too long to read
+	// is skipped instead of failing the extraction
+	want := []string{
+		"lib/naming.rego",
+		"lib/tags/required.rego",
+		"lib/tags/tags.rego",
+		"terraform/aws/EC2/logging_v1.rego",
+		"terraform/aws/EC2/logging_v1_test.rego",
+		"terraform/aws/S3/lib/s3.rego",
+		"terraform/aws/S3/logging_v1.rego",
+	}
+	if got := extractedFiles(t, outputDir); !reflect.DeepEqual(got, want) {
+		t.Errorf("extracted %v, want %v", got, want)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, "terraform/aws/S3/logging_v1.rego"))
+	if err != nil || string(content) != "package rules.s3_logging_v1\n\nimport data.fugue\nimport data.lib.tags\n" {
+		t.Errorf("extracted S3 rule = %q, %v", content, err)
+	}
+}
+
+func TestRegoExtractionCollisions(t *testing.T) {
+	repoPath := t.TempDir()
+	writeFiles(t, repoPath, map[string]string{
+		"terraform/aws/S3/s3_v1.rego":        "package rules.s3_v1\n",
+		"terraform/aws/S3 Legacy/s3_v1.rego": "# legacy copy\npackage rules.s3_v1\n",
+		"terraform/aws/EC2/ec2_v1.rego":      "package rules.ec2_v1\n",
+		"terraform/aws/EC2/ec2_v1_more.rego": "package rules.ec2_v1\n",
+	})
+	extraction, err := newRegoExtraction(repoPath, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := extraction.extractTaxons(filepath.Join(repoPath, "terraform/aws"), []string{"S3", "S3 Legacy", "EC2"}); err != nil {
+		t.Fatalf("extractTaxons() error = %v", err)
+	}
+
+	// A package split over files of one directory is not a collision
+	err = extraction.checkCollisions()
+	var collisionErr *PolicyCollisionError
+	if !errors.As(err, &collisionErr) {
+		t.Fatalf("checkCollisions() error = %v, want a PolicyCollisionError", err)
+	}
+	want := []PolicyCollision{{
+		Package: "data.rules.s3_v1",
+		Files:   []string{"terraform/aws/S3 Legacy/s3_v1.rego", "terraform/aws/S3/s3_v1.rego"},
+	}}
+	if !reflect.DeepEqual(collisionErr.Collisions, want) {
+		t.Errorf("checkCollisions() collisions = %+v, want %+v", collisionErr.Collisions, want)
+	}
+}
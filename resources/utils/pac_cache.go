@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPACCacheTTL is how long a resolved branch or tag is reused
+	// without asking the remote for its current commit.
+	DefaultPACCacheTTL = time.Hour
+	// DefaultPACCacheRetention is how long an unused pack is kept on disk.
+	DefaultPACCacheRetention = 7 * 24 * time.Hour
+
+	// pacCacheTmpRetention is how long an abandoned clone is kept before it is
+	// considered left over by a crashed run.
+	pacCacheTmpRetention = time.Hour
+)
+
+// PACCacheOptions configures the on-disk cache of cloned PAC repositories.
+type PACCacheOptions struct {
+	// Dir is the cache directory, starchitect/pac in the user cache directory when empty.
+	Dir string
+	// TTL is how long a resolved branch or tag is reused without asking the
+	// remote. Zero asks the remote on every fetch. Commit SHAs never expire.
+	TTL time.Duration
+	// Retention is how long an unused pack is kept on disk. Zero keeps packs forever.
+	Retention time.Duration
+}
+
+// PACCache stores one clone per repository and commit, the packs, below
+// Dir/packs and remembers which commit every ref resolved to in Dir/refs.json.
+// Packs are shared by every scan and must not be written to.
+type PACCache struct {
+	dir string
+
+	mu    sync.Mutex
+	refs  map[string]pacCacheRef
+	locks map[string]*sync.Mutex
+}
+
+// pacCacheRef is the commit a ref of a repository resolved to.
+type pacCacheRef struct {
+	// Commit is the checked out commit, the key of the pack.
+	Commit string `json:"commit"`
+	// Advertised is the hash the remote advertised for the ref, which is the
+	// tag object rather than the commit for annotated tags.
+	Advertised string    `json:"advertised,omitempty"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// pacCaches holds the caches opened by this process, by directory, so every
+// resource and data source of a provider reuses the same packs and locks.
+var pacCaches sync.Map
+
+// OpenPACCache returns the cache of opts.Dir. Stale packs and abandoned clones
+// are removed when a directory is opened for the first time.
+func OpenPACCache(opts PACCacheOptions) (*PACCache, error) {
+	dir := opts.Dir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			base = os.TempDir()
+		}
+		dir = filepath.Join(base, "starchitect", "pac")
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if cache, ok := pacCaches.Load(dir); ok {
+		return cache.(*PACCache), nil
+	}
+
+	for _, sub := range []string{"packs", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create PAC cache: %v", err)
+		}
+	}
+	cache := &PACCache{
+		dir:   dir,
+		refs:  map[string]pacCacheRef{},
+		locks: map[string]*sync.Mutex{},
+	}
+	if content, err := os.ReadFile(cache.refsPath()); err == nil {
+		if err := json.Unmarshal(content, &cache.refs); err != nil {
+			log.Printf("Warning: ignoring corrupt PAC cache index %s: %v", cache.refsPath(), err)
+			cache.refs = map[string]pacCacheRef{}
+		}
+	}
+
+	actual, loaded := pacCaches.LoadOrStore(dir, cache)
+	if !loaded {
+		cache.cleanup(opts.Retention, time.Now())
+	}
+	return actual.(*PACCache), nil
+}
+
+func (c *PACCache) refsPath() string {
+	return filepath.Join(c.dir, "refs.json")
+}
+
+// packPath is the content addressed directory of a commit of a repository.
+func (c *PACCache) packPath(repoURL, commit string) string {
+	sum := sha256.Sum256([]byte(repoURL + "@" + strings.ToLower(commit)))
+	return filepath.Join(c.dir, "packs", hex.EncodeToString(sum[:]))
+}
+
+// lock serializes the fetches of a ref so concurrent scans clone it once.
+func (c *PACCache) lock(key string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.locks[key] = lock
+	}
+	return lock
+}
+
+func (c *PACCache) ref(key string) (pacCacheRef, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ref, ok := c.refs[key]
+	return ref, ok
+}
+
+// setRef records a resolved ref and persists the index for later processes.
+func (c *PACCache) setRef(key string, ref pacCacheRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs[key] = ref
+
+	content, err := json.MarshalIndent(c.refs, "", "  ")
+	if err == nil {
+		tmp := c.refsPath() + fmt.Sprintf(".%d.tmp", os.Getpid())
+		if err = os.WriteFile(tmp, content, 0644); err == nil {
+			err = os.Rename(tmp, c.refsPath())
+		}
+	}
+	if err != nil {
+		log.Printf("Warning: failed to write PAC cache index: %v", err)
+	}
+}
+
+// use returns path when the pack exists and marks it as recently used.
+func (c *PACCache) use(path string) bool {
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return true
+}
+
+// Fetch returns the root of the cached clone of source, cloning it when the
+// ref is not cached or its TTL expired and the remote moved on. When the
+// remote cannot be reached, a previously resolved pack is reused.
+func (c *PACCache) Fetch(source PACSource) (string, error) {
+	packPath, _, err := c.fetch(source)
+	return packPath, err
+}
+
+// fetch implements Fetch and also returns the commit of the pack.
+func (c *PACCache) fetch(source PACSource) (string, string, error) {
+	repoURL := source.repository()
+	ref := source.ref()
+	key := repoURL + "@" + ref
+
+	lock := c.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Full commit SHAs address a pack without asking the remote
+	if len(ref) == 40 && CommitSHARegex.MatchString(ref) && c.use(c.packPath(repoURL, ref)) {
+		return c.packPath(repoURL, ref), ref, nil
+	}
+
+	cached, ok := c.ref(key)
+	cachedPath := ""
+	if ok && c.use(c.packPath(repoURL, cached.Commit)) {
+		cachedPath = c.packPath(repoURL, cached.Commit)
+		isCommit := cached.Advertised == "" && CommitSHARegex.MatchString(ref)
+		if isCommit || time.Since(cached.ResolvedAt) < source.Cache.TTL {
+			return cachedPath, cached.Commit, nil
+		}
+	}
+
+	auth, err := source.Auth.method()
+	if err != nil {
+		return "", "", &PACFetchError{Source: repoURL, Err: fmt.Errorf("invalid credentials: %v", err)}
+	}
+
+	referenceName, advertised, err := resolveRef(repoURL, ref, auth)
+	if err != nil {
+		if cachedPath != "" {
+			log.Printf("Warning: failed to refresh %s of %s, using cached commit %s: %v", ref, repoURL, cached.Commit, err)
+			return cachedPath, cached.Commit, nil
+		}
+		return "", "", &PACFetchError{Source: repoURL, Err: err}
+	}
+	if cachedPath != "" && referenceName != "" && cached.Advertised == advertised.String() {
+		cached.ResolvedAt = time.Now()
+		c.setRef(key, cached)
+		return cachedPath, cached.Commit, nil
+	}
+
+	tmpDir, err := os.MkdirTemp(filepath.Join(c.dir, "tmp"), "pac-clone-*")
+	if err != nil {
+		return "", "", &PACFetchError{Source: repoURL, Err: err}
+	}
+	defer os.RemoveAll(tmpDir)
+
+	commit, err := clonePAC(tmpDir, repoURL, ref, referenceName, auth)
+	if err != nil {
+		return "", "", &PACFetchError{Source: repoURL, Err: err}
+	}
+
+	packPath := c.packPath(repoURL, commit.String())
+	if !c.use(packPath) {
+		if err := os.Rename(tmpDir, packPath); err != nil && !c.use(packPath) {
+			return "", "", &PACFetchError{Source: repoURL, Err: fmt.Errorf("failed to store clone in cache: %v", err)}
+		}
+	}
+
+	resolved := pacCacheRef{Commit: commit.String(), ResolvedAt: time.Now()}
+	if referenceName != "" {
+		resolved.Advertised = advertised.String()
+	}
+	c.setRef(key, resolved)
+	return packPath, resolved.Commit, nil
+}
+
+// cleanup removes the packs unused for longer than retention and the clones
+// abandoned by crashed runs.
+func (c *PACCache) cleanup(retention time.Duration, now time.Time) {
+	remove := func(sub string, maxAge time.Duration) {
+		entries, err := os.ReadDir(filepath.Join(c.dir, sub))
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || now.Sub(info.ModTime()) <= maxAge {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(c.dir, sub, entry.Name())); err != nil {
+				log.Printf("Warning: failed to remove stale PAC cache entry %s: %v", entry.Name(), err)
+			}
+		}
+	}
+	if retention > 0 {
+		remove("packs", retention)
+	}
+	remove("tmp", pacCacheTmpRetention)
+}
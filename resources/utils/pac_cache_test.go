@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPACCacheFetch(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	cache, err := OpenPACCache(PACCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := PACSource{Repository: fixture.url, Cache: PACCacheOptions{TTL: time.Hour}}
+
+	first, err := cache.Fetch(source)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if filepath.Base(first) == "" || first != cache.packPath(fixture.url, fixture.secondCommit.String()) {
+		t.Errorf("Fetch() = %s, want the pack of %s", first, fixture.secondCommit)
+	}
+
+	// Within the TTL the ref is not resolved again, even when the remote moved on
+	fixture.commit(t, map[string]string{"terraform/aws/s3/s3_v3.rego": "package rules.s3_v3\n"})
+	second, err := cache.Fetch(source)
+	if err != nil || second != first {
+		t.Errorf("Fetch() within TTL = %s, %v, want the cached %s", second, err, first)
+	}
+
+	// Once the TTL expired the new commit is cloned into its own pack
+	source.Cache.TTL = 0
+	third, err := cache.Fetch(source)
+	if err != nil {
+		t.Fatalf("Fetch() after TTL error = %v", err)
+	}
+	if third == first {
+		t.Errorf("Fetch() after TTL reused %s after the remote moved on", first)
+	}
+	if _, err := os.Stat(filepath.Join(third, "terraform/aws/s3/s3_v3.rego")); err != nil {
+		t.Errorf("Fetch() after TTL is missing the new commit: %v", err)
+	}
+	if _, err := os.Stat(first); err != nil {
+		t.Errorf("Fetch() removed the pack of the previous commit: %v", err)
+	}
+
+	// An unchanged remote does not clone again
+	entries, _ := os.ReadDir(filepath.Join(cache.dir, "packs"))
+	if fourth, err := cache.Fetch(source); err != nil || fourth != third {
+		t.Errorf("Fetch() of an unchanged ref = %s, %v, want %s", fourth, err, third)
+	}
+	if after, _ := os.ReadDir(filepath.Join(cache.dir, "packs")); len(after) != len(entries) {
+		t.Errorf("Fetch() of an unchanged ref created a pack")
+	}
+
+	// Commit SHAs are served from the cache without the remote
+	if err := os.RemoveAll(fixture.url); err != nil {
+		t.Fatal(err)
+	}
+	pinned, err := cache.Fetch(PACSource{Repository: fixture.url, Ref: fixture.secondCommit.String()})
+	if err != nil || pinned != first {
+		t.Errorf("Fetch() of a cached commit = %s, %v, want %s", pinned, err, first)
+	}
+
+	// An unreachable remote falls back to the last resolved commit
+	if offline, err := cache.Fetch(source); err != nil || offline != third {
+		t.Errorf("Fetch() with an unreachable remote = %s, %v, want %s", offline, err, third)
+	}
+	if _, err := cache.Fetch(PACSource{Repository: fixture.url, Ref: "release"}); err == nil {
+		t.Errorf("Fetch() of an uncached ref succeeded without the remote")
+	}
+}
+
+func TestPACCacheIndexSharedAcrossProcesses(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	dir := t.TempDir()
+	cache, err := OpenPACCache(PACCacheOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := PACSource{Repository: fixture.url, Ref: "v1.0.0", Cache: PACCacheOptions{TTL: time.Hour}}
+	first, err := cache.Fetch(source)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	// A new process reads the index left behind and does not need the remote
+	pacCaches.Delete(cache.dir)
+	if err := os.RemoveAll(fixture.url); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenPACCache(PACCacheOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened == cache {
+		t.Fatalf("OpenPACCache() returned the cache of the previous process")
+	}
+	if second, err := reopened.Fetch(source); err != nil || second != first {
+		t.Errorf("Fetch() of the reopened cache = %s, %v, want %s", second, err, first)
+	}
+}
+
+func TestPACCacheCleanup(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeFiles(t, dir, map[string]string{
+		"packs/stale/rule.rego":     "package rules.stale\n",
+		"packs/fresh/rule.rego":     "package rules.fresh\n",
+		"tmp/pac-clone-1/rule.rego": "package rules.crashed\n",
+		"tmp/pac-clone-2/rule.rego": "package rules.running\n",
+	})
+	old := now.Add(-30 * 24 * time.Hour)
+	for _, path := range []string{"packs/stale", "tmp/pac-clone-1"} {
+		if err := os.Chtimes(filepath.Join(dir, path), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := OpenPACCache(PACCacheOptions{Dir: dir, Retention: DefaultPACCacheRetention})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]bool{
+		"packs/stale":     false,
+		"packs/fresh":     true,
+		"tmp/pac-clone-1": false,
+		"tmp/pac-clone-2": true,
+	} {
+		_, err := os.Stat(filepath.Join(cache.dir, path))
+		if (err == nil) != want {
+			t.Errorf("after cleanup %s exists = %v, want %v", path, err == nil, want)
+		}
+	}
+}
+
+func TestGetDefaultPACCleanup(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	iacPath := t.TempDir()
+	writeFiles(t, iacPath, map[string]string{
+		"main.tf": "resource \"aws_instance\" \"web\" {}\n",
+	})
+
+	pacPath, _, cleanup, err := GetDefaultPAC(mustLoadIaC(t, iacPath), PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}})
+	if err != nil {
+		t.Fatalf("GetDefaultPAC() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pacPath, "terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego")); err != nil {
+		t.Errorf("GetDefaultPAC() did not extract the EC2 rules: %v", err)
+	}
+	cleanup()
+	if _, err := os.Stat(pacPath); !os.IsNotExist(err) {
+		t.Errorf("cleanup() left %s behind", pacPath)
+	}
+}
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// DefaultPACRepository is the repository the default policies are cloned from.
+	DefaultPACRepository = "https://github.com/nonfx/starchitect-cloudguard"
+	// DefaultPACVersion is the ref of DefaultPACRepository used by default.
+	DefaultPACVersion = "main"
+	// DefaultPACSubdirectory is the directory of the repository holding the aws policies.
+	DefaultPACSubdirectory = "terraform/aws"
+)
+
+// PACSource describes the git repository the default policies are fetched from.
+type PACSource struct {
+	// Repository is the git repository URL, DefaultPACRepository when empty.
+	Repository string
+	// Ref is the branch, tag or commit SHA to check out, DefaultPACVersion when empty.
+	Ref string
+	// Subdirectory is the directory holding the aws policies, DefaultPACSubdirectory
+	// when empty. The policies of the other clouds are read from the
+	// subdirectories of the taxonomy.
+	Subdirectory string
+	// TaxonomyFile is a YAML or JSON taxonomy merged over the taxonomy of the
+	// pack, e.g. to map custom resource types.
+	TaxonomyFile string
+	// Auth authenticates against private repositories.
+	Auth PACAuth
+	// Cache configures the on-disk cache of cloned repositories.
+	Cache PACCacheOptions
+	// Offline resolves the ref from the rule packs in RulePackPath instead of
+	// the repository and never touches the network.
+	Offline bool
+	// RulePackPath is a rule pack directory or archive, or a directory of them.
+	RulePackPath string
+	// Verification is checked before the policies of the pack are used.
+	Verification PACVerification
+}
+
+// PACAuth holds the credentials of a private PAC repository. A token is used
+// for HTTP(S) repositories, an SSH private key for SSH repositories.
+type PACAuth struct {
+	Username          string
+	Token             string
+	SSHPrivateKey     string
+	SSHPrivateKeyFile string
+	SSHKeyPassphrase  string
+}
+
+// CommitSHARegex matches full and abbreviated commit SHAs.
+var CommitSHARegex = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+func (s PACSource) repository() string {
+	if s.Repository == "" {
+		return DefaultPACRepository
+	}
+	return s.Repository
+}
+
+func (s PACSource) ref() string {
+	if s.Ref == "" && s.Verification.Commit != "" {
+		return s.Verification.Commit
+	}
+	if s.Ref == "" {
+		return DefaultPACVersion
+	}
+	return s.Ref
+}
+
+func (s PACSource) subdirectory() string {
+	if s.Subdirectory == "" {
+		return DefaultPACSubdirectory
+	}
+	return s.Subdirectory
+}
+
+// cloudSubdirectory returns the directory holding the policies of cloud.
+func (s PACSource) cloudSubdirectory(taxonomy *Taxonomy, cloud string) string {
+	if cloud == CloudAWS && s.Subdirectory != "" {
+		return s.Subdirectory
+	}
+	return taxonomy.subdirectory(cloud)
+}
+
+// method returns the go-git authentication of the credentials, nil without credentials.
+func (a PACAuth) method() (transport.AuthMethod, error) {
+	switch {
+	case a.SSHPrivateKey != "" || a.SSHPrivateKeyFile != "":
+		username := a.Username
+		if username == "" {
+			username = "git"
+		}
+		if a.SSHPrivateKey != "" {
+			return gitssh.NewPublicKeys(username, []byte(a.SSHPrivateKey), a.SSHKeyPassphrase)
+		}
+		return gitssh.NewPublicKeysFromFile(username, a.SSHPrivateKeyFile, a.SSHKeyPassphrase)
+	case a.Token != "":
+		username := a.Username
+		if username == "" {
+			// most Git servers accept any non-empty username together with a token
+			username = "git"
+		}
+		return &githttp.BasicAuth{Username: username, Password: a.Token}, nil
+	}
+	return nil, nil
+}
+
+// resolveRef finds the branch or tag named ref on the remote and the hash it
+// points to. An empty reference name is returned when ref is neither, e.g.
+// for commit SHAs.
+func resolveRef(repoURL, ref string, auth transport.AuthMethod) (plumbing.ReferenceName, plumbing.Hash, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{repoURL},
+	})
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	candidates := []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.NewTagReferenceName(ref),
+	}
+	if strings.HasPrefix(ref, "refs/") {
+		candidates = []plumbing.ReferenceName{plumbing.ReferenceName(ref)}
+	}
+	for _, candidate := range candidates {
+		for _, remoteRef := range refs {
+			if remoteRef.Name() == candidate {
+				return candidate, remoteRef.Hash(), nil
+			}
+		}
+	}
+	return "", plumbing.ZeroHash, nil
+}
+
+// clonePAC clones ref of the repository into dir and returns the checked out
+// commit. Branches and tags are cloned shallowly, commit SHAs need the full
+// history to be checked out.
+func clonePAC(dir, repoURL, ref string, referenceName plumbing.ReferenceName, auth transport.AuthMethod) (plumbing.Hash, error) {
+	if referenceName != "" {
+		repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+			URL:           repoURL,
+			Auth:          auth,
+			Depth:         1,
+			SingleBranch:  true,
+			ReferenceName: referenceName,
+		})
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return head.Hash(), nil
+	}
+
+	if !CommitSHARegex.MatchString(ref) {
+		return plumbing.ZeroHash, fmt.Errorf("ref %q is not a branch, tag or commit SHA of the repository", ref)
+	}
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:        repoURL,
+		Auth:       auth,
+		NoCheckout: true,
+	})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("commit %s not found: %v", ref, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: *hash}); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to check out %s: %v", ref, err)
+	}
+	return *hash, nil
+}
+
+// pacCheckout is a fetched PAC repository and the taxonomy of its policies.
+type pacCheckout struct {
+	path   string
+	commit string
+	// taxonomyFile is the taxonomy file of the pack, empty when the embedded default is used.
+	taxonomyFile string
+	taxonomy     *Taxonomy
+}
+
+// getPACPath fetches the PAC repository through the cache and loads its taxonomy.
+func getPACPath(cache *PACCache, source PACSource) (*pacCheckout, error) {
+	fetch := cache.fetch
+	if source.Offline {
+		fetch = cache.fetchRulePack
+	}
+	repoPath, commit, err := fetch(source)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy, taxonomyFile, err := loadTaxonomy(repoPath, source.TaxonomyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &pacCheckout{path: repoPath, commit: commit, taxonomyFile: taxonomyFile, taxonomy: taxonomy}, nil
+}
+
+// folders verifies the policy folders of the clouds and returns their paths by
+// cloud. The aws folder is required, the folders of the other clouds are
+// skipped with a warning when the repository has none.
+func (c *pacCheckout) folders(source PACSource, clouds ...string) (map[string]string, error) {
+	folders := map[string]string{}
+	policyPaths := []string{}
+	if c.taxonomyFile != "" {
+		// the taxonomy selects the policies and is verified like them
+		policyPaths = append(policyPaths, c.taxonomyFile)
+	}
+	for _, cloud := range clouds {
+		folderPath := source.cloudSubdirectory(c.taxonomy, cloud)
+		clonedFolderPath := filepath.Join(c.path, folderPath)
+		if _, err := os.Stat(clonedFolderPath); os.IsNotExist(err) {
+			if cloud == CloudAWS {
+				return nil, &PACFetchError{Source: source.repository(), Err: fmt.Errorf("folder %s does not exist in the cloned repository", folderPath)}
+			}
+			log.Printf("Warning: folder %s of the %s policies does not exist in the cloned repository, skipping", folderPath, cloud)
+			continue
+		}
+		folders[cloud] = clonedFolderPath
+		policyPaths = append(policyPaths, clonedFolderPath)
+	}
+	if err := source.Verification.verify(c.path, c.commit, policyPaths...); err != nil {
+		return nil, &PACVerificationError{Source: source.repository(), Err: err}
+	}
+	return folders, nil
+}
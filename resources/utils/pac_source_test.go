@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// pacRepoFixture is a bare repository with two commits on main, a tag on the
+// first commit and a release branch adding a custom policy directory.
+type pacRepoFixture struct {
+	url          string
+	workDir      string
+	repo         *git.Repository
+	firstCommit  plumbing.Hash
+	secondCommit plumbing.Hash
+}
+
+// commit adds files to main and pushes them to the bare repository.
+func (f pacRepoFixture) commit(t *testing.T, files map[string]string) plumbing.Hash {
+	t.Helper()
+	hash := commitFiles(t, f.repo, f.workDir, files, "update")
+	err := f.repo.Push(&git.PushOptions{
+		RefSpecs: []config.RefSpec{"refs/heads/main:refs/heads/main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func commitFiles(t *testing.T, repo *git.Repository, dir string, files map[string]string, message string) plumbing.Hash {
+	t.Helper()
+	writeFiles(t, dir, files)
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worktree.AddGlob("."); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func newPACRepoFixture(t *testing.T) pacRepoFixture {
+	t.Helper()
+	workDir := t.TempDir()
+	repo, err := git.PlainInit(workDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// PlainInit points HEAD at master, the fixture uses main like cloudguard
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))); err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := pacRepoFixture{}
+	fixture.firstCommit = commitFiles(t, repo, workDir, map[string]string{
+		"terraform/aws/s3/s3_v1.rego":                                  "package rules.s3_v1\n",
+		"terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego": "package rules.ec2_v1\n",
+	}, "first")
+	if _, err := repo.CreateTag("v1.0.0", fixture.firstCommit, nil); err != nil {
+		t.Fatal(err)
+	}
+	fixture.secondCommit = commitFiles(t, repo, workDir, map[string]string{
+		"terraform/aws/s3/s3_v2.rego": "package rules.s3_v2\n",
+	}, "second")
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("release"), Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	commitFiles(t, repo, workDir, map[string]string{
+		"internal/aws/s3/s3_internal.rego": "package rules.s3_internal\n",
+	}, "internal rules")
+	if err := worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("main")}); err != nil {
+		t.Fatal(err)
+	}
+
+	bareDir := filepath.Join(t.TempDir(), "rules.git")
+	if _, err := git.PlainClone(bareDir, true, &git.CloneOptions{URL: workDir, Mirror: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{bareDir}}); err != nil {
+		t.Fatal(err)
+	}
+	fixture.url = bareDir
+	fixture.workDir = workDir
+	fixture.repo = repo
+	return fixture
+}
+
+func TestGetPACPath(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+
+	tests := []struct {
+		name      string
+		source    PACSource
+		wantFiles []string
+		wantErr   bool
+		// wantVerifyErr expects a *PACVerificationError instead of a *PACFetchError
+		wantVerifyErr bool
+	}{
+		{
+			name:      "default branch",
+			source:    PACSource{Repository: fixture.url},
+			wantFiles: []string{"s3/s3_v1.rego", "s3/s3_v2.rego"},
+		},
+		{
+			name:      "tag",
+			source:    PACSource{Repository: fixture.url, Ref: "v1.0.0"},
+			wantFiles: []string{"s3/s3_v1.rego"},
+		},
+		{
+			name:      "commit SHA",
+			source:    PACSource{Repository: fixture.url, Ref: fixture.firstCommit.String()},
+			wantFiles: []string{"s3/s3_v1.rego"},
+		},
+		{
+			name:      "abbreviated commit SHA",
+			source:    PACSource{Repository: fixture.url, Ref: fixture.secondCommit.String()[:10]},
+			wantFiles: []string{"s3/s3_v1.rego", "s3/s3_v2.rego"},
+		},
+		{
+			name:      "branch and subdirectory",
+			source:    PACSource{Repository: fixture.url, Ref: "release", Subdirectory: "internal/aws"},
+			wantFiles: []string{"s3/s3_internal.rego"},
+		},
+		{
+			name:      "pinned commit",
+			source:    PACSource{Repository: fixture.url, Verification: PACVerification{Commit: fixture.firstCommit.String()}},
+			wantFiles: []string{"s3/s3_v1.rego"},
+		},
+		{
+			name:          "branch moved past the pinned commit",
+			source:        PACSource{Repository: fixture.url, Ref: "main", Verification: PACVerification{Commit: fixture.firstCommit.String()[:12]}},
+			wantErr:       true,
+			wantVerifyErr: true,
+		},
+		{
+			name:    "unknown ref",
+			source:  PACSource{Repository: fixture.url, Ref: "does-not-exist"},
+			wantErr: true,
+		},
+		{
+			name:    "missing subdirectory",
+			source:  PACSource{Repository: fixture.url, Subdirectory: "terraform/azure"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, err := OpenPACCache(PACCacheOptions{Dir: t.TempDir()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var folders map[string]string
+			checkout, err := getPACPath(cache, tt.source)
+			if err == nil {
+				folders, err = checkout.folders(tt.source, CloudAWS)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getPACPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*PACVerificationError); ok != tt.wantVerifyErr {
+					t.Errorf("getPACPath() error = %v, want a verification error %v", err, tt.wantVerifyErr)
+				}
+				if _, ok := err.(*PACFetchError); !ok && !tt.wantVerifyErr {
+					t.Errorf("getPACPath() error type = %T, want *PACFetchError", err)
+				}
+				return
+			}
+			for _, file := range tt.wantFiles {
+				if _, err := os.Stat(filepath.Join(folders[CloudAWS], file)); err != nil {
+					t.Errorf("getPACPath() is missing %s: %v", file, err)
+				}
+			}
+			if len(tt.wantFiles) == 1 {
+				if _, err := os.Stat(filepath.Join(folders[CloudAWS], "s3/s3_v2.rego")); err == nil && tt.wantFiles[0] != "s3/s3_v2.rego" {
+					t.Errorf("getPACPath() checked out a newer revision than %s", tt.source.Ref)
+				}
+			}
+		})
+	}
+}
+
+func TestPACAuthMethod(t *testing.T) {
+	method, err := PACAuth{}.method()
+	if err != nil || method != nil {
+		t.Errorf("method() without credentials = %v, %v, want nil", method, err)
+	}
+
+	method, err = PACAuth{Token: "secret"}.method()
+	if err != nil || method == nil || method.Name() != "http-basic-auth" {
+		t.Errorf("method() with a token = %v, %v, want basic auth", method, err)
+	}
+
+	if _, err := (PACAuth{SSHPrivateKey: "not a key"}).method(); err == nil {
+		t.Errorf("method() accepted an invalid SSH private key")
+	}
+}
@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PACVerification configures how a fetched rule pack is verified before its
+// policies are used. The zero value trusts the pack.
+type PACVerification struct {
+	// Commit pins the pack to a full or abbreviated commit SHA. It is also the
+	// ref fetched when the source has none.
+	Commit string
+	// ChecksumFile is a sha256sum style manifest, "<sha256>  <path>" per line,
+	// of the .rego files. Relative paths are read from the pack, paths in the
+	// manifest are relative to the root of the pack.
+	ChecksumFile string
+	// PublicKey is the PEM encoded ed25519 public key the checksum manifest must be signed with.
+	PublicKey string
+	// SignatureFile holds the base64 encoded ed25519 signature of ChecksumFile,
+	// ChecksumFile with a .sig suffix when empty.
+	SignatureFile string
+}
+
+// PACVerificationError is returned when a fetched rule pack fails verification,
+// e.g. because it has been tampered with.
+type PACVerificationError struct {
+	// Source is the repository or rule pack the policies were fetched from.
+	Source string
+	Err    error
+}
+
+func (e *PACVerificationError) Error() string {
+	return fmt.Sprintf("policies fetched from %s failed verification: %v", e.Source, e.Err)
+}
+
+func (e *PACVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// packFile resolves a file of the verification configuration, relative to the pack root.
+func packFile(packPath, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(packPath, name)
+}
+
+// verify checks the pack checked out at commit. Every .rego file below the
+// policyPaths, and every policyPath that is a file, must be listed in the
+// checksum manifest.
+func (v PACVerification) verify(packPath, commit string, policyPaths ...string) error {
+	if v.Commit != "" {
+		if !CommitSHARegex.MatchString(v.Commit) {
+			return fmt.Errorf("pinned commit %q is not a commit SHA", v.Commit)
+		}
+		if !strings.HasPrefix(strings.ToLower(commit), strings.ToLower(v.Commit)) {
+			return fmt.Errorf("pack is at commit %s, want the pinned commit %s", commit, v.Commit)
+		}
+	}
+	if v.ChecksumFile == "" {
+		if v.PublicKey != "" {
+			return fmt.Errorf("a public key requires a checksum file to verify the signature of")
+		}
+		return nil
+	}
+
+	manifest, err := os.ReadFile(packFile(packPath, v.ChecksumFile))
+	if err != nil {
+		return fmt.Errorf("failed to read checksum file: %v", err)
+	}
+	if v.PublicKey != "" {
+		signatureFile := v.SignatureFile
+		if signatureFile == "" {
+			signatureFile = v.ChecksumFile + ".sig"
+		}
+		if err := verifySignature(manifest, packFile(packPath, signatureFile), v.PublicKey); err != nil {
+			return err
+		}
+	}
+
+	checksums, err := parseChecksums(manifest)
+	if err != nil {
+		return fmt.Errorf("invalid checksum file %s: %v", v.ChecksumFile, err)
+	}
+	return verifyChecksums(packPath, policyPaths, checksums)
+}
+
+// verifySignature checks the ed25519 signature of the checksum manifest.
+func verifySignature(manifest []byte, signatureFile, publicKey string) error {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return fmt.Errorf("public key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key is a %T, want an ed25519 key", parsed)
+	}
+
+	content, err := os.ReadFile(signatureFile)
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %v", err)
+	}
+	signature := content
+	if len(content) != ed25519.SignatureSize {
+		signature, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil {
+			return fmt.Errorf("signature %s is neither raw nor base64 encoded: %v", signatureFile, err)
+		}
+	}
+	if !ed25519.Verify(key, manifest, signature) {
+		return fmt.Errorf("signature of the checksum file does not match the public key")
+	}
+	return nil
+}
+
+// parseChecksums reads a sha256sum style manifest into checksums by slash separated path.
+func parseChecksums(manifest []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sum, name, ok := strings.Cut(text, " ")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		if decoded, err := hex.DecodeString(sum); !ok || err != nil || len(decoded) != sha256.Size || name == "" {
+			return nil, fmt.Errorf("line %d: want \"<sha256>  <path>\"", line)
+		}
+		name = path.Clean(filepath.ToSlash(name))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("line %d: %s is outside the pack", line, name)
+		}
+		checksums[name] = strings.ToLower(sum)
+	}
+	return checksums, scanner.Err()
+}
+
+// verifyChecksums compares the files of the pack with their checksums and
+// requires every .rego file below the policyPaths, and every policyPath that is
+// a file, to be listed.
+func verifyChecksums(packPath string, policyPaths []string, checksums map[string]string) error {
+	problems := []string{}
+	for name, want := range checksums {
+		content, err := os.ReadFile(filepath.Join(packPath, filepath.FromSlash(name)))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s is missing", name))
+			continue
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != want {
+			problems = append(problems, fmt.Sprintf("%s does not match its checksum", name))
+		}
+	}
+
+	for _, policyPath := range policyPaths {
+		err := filepath.WalkDir(policyPath, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || (file != policyPath && !strings.HasSuffix(entry.Name(), ".rego")) {
+				return nil
+			}
+			rel, err := filepath.Rel(packPath, file)
+			if err != nil {
+				return err
+			}
+			if _, ok := checksums[filepath.ToSlash(rel)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s is not listed in the checksum file", filepath.ToSlash(rel)))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
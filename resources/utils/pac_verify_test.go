@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPACVerificationVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	otherPublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ = x509.MarshalPKIXPublicKey(otherPublicKey)
+	otherPublicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	s3Rule := "package rules.s3_v1\n"
+	ec2Rule := "package rules.ec2_v1\n"
+	checksums := fmt.Sprintf("# generated by sha256sum\n%s  terraform/aws/s3/s3_v1.rego\n%s *terraform/aws/ec2/ec2_v1.rego\n",
+		sha256Hex(s3Rule), sha256Hex(ec2Rule))
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(checksums)))
+
+	newPack := func(t *testing.T, files map[string]string) (string, string) {
+		dir := t.TempDir()
+		pack := map[string]string{
+			"terraform/aws/s3/s3_v1.rego":   s3Rule,
+			"terraform/aws/ec2/ec2_v1.rego": ec2Rule,
+			"checksums.sha256":              checksums,
+			"checksums.sha256.sig":          signature + "\n",
+		}
+		for name, content := range files {
+			pack[name] = content
+		}
+		writeFiles(t, dir, pack)
+		return dir, filepath.Join(dir, "terraform/aws")
+	}
+
+	tests := []struct {
+		name         string
+		verification PACVerification
+		files        map[string]string
+		wantErr      string
+	}{
+		{
+			name:         "nothing to verify",
+			verification: PACVerification{},
+		},
+		{
+			name:         "pinned commit",
+			verification: PACVerification{Commit: "0123456789AB"},
+		},
+		{
+			name:         "other pinned commit",
+			verification: PACVerification{Commit: "fedcba98"},
+			wantErr:      "want the pinned commit fedcba98",
+		},
+		{
+			name:         "matching checksums",
+			verification: PACVerification{ChecksumFile: "checksums.sha256"},
+		},
+		{
+			name:         "tampered rule",
+			verification: PACVerification{ChecksumFile: "checksums.sha256"},
+			files:        map[string]string{"terraform/aws/s3/s3_v1.rego": "package rules.s3_v1\ndeny := false\n"},
+			wantErr:      "terraform/aws/s3/s3_v1.rego does not match its checksum",
+		},
+		{
+			name:         "unlisted rule",
+			verification: PACVerification{ChecksumFile: "checksums.sha256"},
+			files:        map[string]string{"terraform/aws/s3/s3_extra.rego": "package rules.s3_extra\n"},
+			wantErr:      "terraform/aws/s3/s3_extra.rego is not listed",
+		},
+		{
+			name:         "malformed checksum file",
+			verification: PACVerification{ChecksumFile: "checksums.sha256"},
+			files:        map[string]string{"checksums.sha256": "not a checksum\n"},
+			wantErr:      "line 1",
+		},
+		{
+			name:         "checksum of a file outside the pack",
+			verification: PACVerification{ChecksumFile: "checksums.sha256"},
+			files:        map[string]string{"checksums.sha256": sha256Hex(s3Rule) + "  ../s3_v1.rego\n"},
+			wantErr:      "outside the pack",
+		},
+		{
+			name:         "valid signature",
+			verification: PACVerification{ChecksumFile: "checksums.sha256", PublicKey: publicKeyPEM},
+		},
+		{
+			name:         "signature of another key",
+			verification: PACVerification{ChecksumFile: "checksums.sha256", PublicKey: otherPublicKeyPEM},
+			wantErr:      "does not match the public key",
+		},
+		{
+			name:         "re-generated checksums without a new signature",
+			verification: PACVerification{ChecksumFile: "checksums.sha256", PublicKey: publicKeyPEM},
+			files: map[string]string{
+				"terraform/aws/s3/s3_v1.rego": "package rules.s3_v1\ndeny := false\n",
+				"checksums.sha256":            sha256Hex("package rules.s3_v1\ndeny := false\n") + "  terraform/aws/s3/s3_v1.rego\n",
+			},
+			wantErr: "does not match the public key",
+		},
+		{
+			name:         "missing signature",
+			verification: PACVerification{ChecksumFile: "checksums.sha256", PublicKey: publicKeyPEM, SignatureFile: "missing.sig"},
+			wantErr:      "failed to read signature",
+		},
+		{
+			name:         "public key without checksum file",
+			verification: PACVerification{PublicKey: publicKeyPEM},
+			wantErr:      "requires a checksum file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packPath, policyPath := newPack(t, tt.files)
+			err := tt.verification.verify(packPath, commit, policyPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verify() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetDefaultPACVerifiesLibraries(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	rule := "package rules.ec2_v1\n\nimport data.lib.tags\n"
+	library := "package lib.tags\n"
+	fixture.commit(t, map[string]string{
+		"terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego": rule,
+		"lib/tags/tags.rego": library,
+	})
+	iacPath := t.TempDir()
+	writeFiles(t, iacPath, map[string]string{"main.tf": `resource "aws_instance" "web" {}`})
+
+	// The policy folder is listed, the library imported from outside of it is not
+	checksums := fmt.Sprintf("%s  terraform/aws/s3/s3_v1.rego\n%s  terraform/aws/s3/s3_v2.rego\n%s  terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego\n",
+		sha256Hex("package rules.s3_v1\n"), sha256Hex("package rules.s3_v2\n"), sha256Hex(rule))
+	fixture.commit(t, map[string]string{"checksums.sha256": checksums})
+	source := PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}, Verification: PACVerification{ChecksumFile: "checksums.sha256"}}
+	_, _, _, err := GetDefaultPAC(mustLoadIaC(t, iacPath), source)
+	var verifyErr *PACVerificationError
+	if !errors.As(err, &verifyErr) || !strings.Contains(err.Error(), "lib/tags/tags.rego is not listed") {
+		t.Errorf("GetDefaultPAC(unlisted library) error = %v, want a verification error", err)
+	}
+
+	fixture.commit(t, map[string]string{"checksums.sha256": checksums + sha256Hex(library) + "  lib/tags/tags.rego\n"})
+	source.Cache = PACCacheOptions{Dir: t.TempDir()}
+	pacPath, _, cleanup, err := GetDefaultPAC(mustLoadIaC(t, iacPath), source)
+	if err != nil {
+		t.Fatalf("GetDefaultPAC(listed library) error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(pacPath, "lib/tags/tags.rego")); err != nil {
+		t.Errorf("GetDefaultPAC() did not extract the library: %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RulePackManifestFile is the manifest at the root of every rule pack.
+const RulePackManifestFile = "manifest.json"
+
+// RulePackManifest records where the files of a rule pack were taken from.
+// A rule pack is a directory, or a .tar, .tar.gz or .tgz archive of it,
+// holding the manifest next to a checkout of the PAC repository, e.g.
+//
+//	manifest.json
+//	terraform/aws/...
+type RulePackManifest struct {
+	// Repository is the URL of the PAC repository.
+	Repository string `json:"repository"`
+	// Commit is the full SHA of the packed commit.
+	Commit string `json:"commit"`
+	// Refs are the branches and tags the commit was packed for, e.g. main or v1.2.0.
+	Refs []string `json:"refs"`
+}
+
+// rulePack is a rule pack found below the rule pack path.
+type rulePack struct {
+	path     string
+	archive  bool
+	manifest RulePackManifest
+}
+
+func (p rulePack) String() string {
+	refs := append([]string{p.manifest.Commit}, p.manifest.Refs...)
+	return fmt.Sprintf("%s@%s", p.manifest.Repository, strings.Join(refs, ","))
+}
+
+// normalizeRepository makes repository URLs comparable, e.g. a trailing .git is ignored.
+func normalizeRepository(url string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(url), "/"), ".git")
+}
+
+// matches reports whether the pack holds ref, a branch, tag or commit SHA, of the repository.
+func (m RulePackManifest) matches(repository, ref string) bool {
+	if normalizeRepository(m.Repository) != normalizeRepository(repository) {
+		return false
+	}
+	for _, packed := range m.Refs {
+		if packed == ref {
+			return true
+		}
+	}
+	return CommitSHARegex.MatchString(ref) && strings.HasPrefix(strings.ToLower(m.Commit), strings.ToLower(ref))
+}
+
+func isRulePackArchive(path string) bool {
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// openArchive iterates over the entries of a tar archive, gzip compressed or not.
+func openArchive(path string, visit func(header *tar.Header, content io.Reader) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if !strings.HasSuffix(path, ".tar") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := visit(header, archive); err != nil {
+			return err
+		}
+	}
+}
+
+// errManifestFound stops reading an archive once its manifest was read.
+var errManifestFound = fmt.Errorf("manifest found")
+
+func readRulePackManifest(path string, archive bool) (RulePackManifest, error) {
+	manifest := RulePackManifest{}
+	var content []byte
+	var err error
+	if archive {
+		err = openArchive(path, func(header *tar.Header, reader io.Reader) error {
+			if filepath.Clean(header.Name) != RulePackManifestFile {
+				return nil
+			}
+			data, readErr := io.ReadAll(reader)
+			if readErr != nil {
+				return readErr
+			}
+			content = data
+			return errManifestFound
+		})
+		if err == errManifestFound {
+			err = nil
+		} else if err == nil {
+			err = fmt.Errorf("%s not found", RulePackManifestFile)
+		}
+	} else {
+		content, err = os.ReadFile(filepath.Join(path, RulePackManifestFile))
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid %s: %v", RulePackManifestFile, err)
+	}
+	if manifest.Repository == "" || manifest.Commit == "" {
+		return manifest, fmt.Errorf("%s must record the repository and commit", RulePackManifestFile)
+	}
+	return manifest, nil
+}
+
+// findRulePacks returns the rule pack at path, or the rule packs directly below it.
+func findRulePacks(path string) ([]rulePack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() || fileExists(filepath.Join(path, RulePackManifestFile)) {
+		manifest, err := readRulePackManifest(path, !info.IsDir())
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule pack %s: %v", path, err)
+		}
+		return []rulePack{{path: path, archive: !info.IsDir(), manifest: manifest}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	packs := []rulePack{}
+	for _, entry := range entries {
+		packPath := filepath.Join(path, entry.Name())
+		archive := !entry.IsDir() && isRulePackArchive(entry.Name())
+		if !archive && !(entry.IsDir() && fileExists(filepath.Join(packPath, RulePackManifestFile))) {
+			continue
+		}
+		manifest, err := readRulePackManifest(packPath, archive)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule pack %s: %v", packPath, err)
+		}
+		packs = append(packs, rulePack{path: packPath, archive: archive, manifest: manifest})
+	}
+	return packs, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// extractRulePack extracts an archived rule pack into dir, rejecting entries
+// escaping it.
+func extractRulePack(archivePath, dir string) error {
+	return openArchive(archivePath, func(header *tar.Header, content io.Reader) error {
+		target := filepath.Join(dir, filepath.Clean(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("entry %s escapes the rule pack", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(file, content)
+			return err
+		}
+		// links and devices are not needed by rule packs
+		return nil
+	})
+}
+
+// FetchRulePack returns the root of the rule pack holding the ref of source
+// without touching the network. Archived packs are extracted into the cache.
+func (c *PACCache) FetchRulePack(source PACSource) (string, error) {
+	packPath, _, err := c.fetchRulePack(source)
+	return packPath, err
+}
+
+// fetchRulePack implements FetchRulePack and also returns the commit of the pack.
+func (c *PACCache) fetchRulePack(source PACSource) (string, string, error) {
+	repoURL := source.repository()
+	ref := source.ref()
+	if source.RulePackPath == "" {
+		return "", "", &PACFetchError{Source: repoURL, Err: fmt.Errorf("offline mode requires a rule pack path holding %s of the repository", ref)}
+	}
+
+	packs, err := findRulePacks(source.RulePackPath)
+	if err != nil {
+		return "", "", &PACFetchError{Source: source.RulePackPath, Err: err}
+	}
+	var pack *rulePack
+	available := []string{}
+	for i := range packs {
+		available = append(available, packs[i].String())
+		if pack == nil && packs[i].manifest.matches(repoURL, ref) {
+			pack = &packs[i]
+		}
+	}
+	if pack == nil {
+		sort.Strings(available)
+		if len(available) == 0 {
+			available = append(available, "none")
+		}
+		return "", "", &PACFetchError{
+			Source: source.RulePackPath,
+			Err: fmt.Errorf("pac_version %q of %s is not available offline, rule packs found: %s",
+				ref, repoURL, strings.Join(available, "; ")),
+		}
+	}
+	if !pack.archive {
+		return pack.path, pack.manifest.Commit, nil
+	}
+
+	lock := c.lock(repoURL + "@" + pack.manifest.Commit)
+	lock.Lock()
+	defer lock.Unlock()
+
+	packPath := c.packPath(repoURL, pack.manifest.Commit)
+	if c.use(packPath) {
+		return packPath, pack.manifest.Commit, nil
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Join(c.dir, "tmp"), "pac-pack-*")
+	if err != nil {
+		return "", "", &PACFetchError{Source: pack.path, Err: err}
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := extractRulePack(pack.path, tmpDir); err != nil {
+		return "", "", &PACFetchError{Source: pack.path, Err: fmt.Errorf("failed to extract rule pack: %v", err)}
+	}
+	if err := os.Rename(tmpDir, packPath); err != nil && !c.use(packPath) {
+		return "", "", &PACFetchError{Source: pack.path, Err: fmt.Errorf("failed to store rule pack in cache: %v", err)}
+	}
+	return packPath, pack.manifest.Commit, nil
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	rulePackRepository = "https://github.com/example/policies"
+	rulePackCommit     = "0123456789abcdef0123456789abcdef01234567"
+)
+
+func rulePackManifest(commit string, refs ...string) string {
+	return `{"repository": "` + rulePackRepository + `.git", "commit": "` + commit + `", "refs": ["` + strings.Join(refs, `", "`) + `"]}`
+}
+
+// writeRulePackArchive writes files into a gzip compressed tar archive.
+func writeRulePackArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	archive := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFetchRulePack(t *testing.T) {
+	cache, err := OpenPACCache(PACCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	packs := t.TempDir()
+	writeFiles(t, filepath.Join(packs, "main"), map[string]string{
+		RulePackManifestFile:          rulePackManifest(rulePackCommit, "main"),
+		"terraform/aws/s3/s3_v1.rego": "package rules.s3_v1\n",
+	})
+	writeRulePackArchive(t, filepath.Join(packs, "v1.0.0.tgz"), map[string]string{
+		"./" + RulePackManifestFile:   rulePackManifest("fedcba9876543210fedcba9876543210fedcba98", "v1.0.0"),
+		"terraform/aws/s3/s3_v0.rego": "package rules.s3_v0\n",
+	})
+	writeFiles(t, packs, map[string]string{"README.md": "not a rule pack"})
+
+	source := PACSource{Repository: rulePackRepository, Offline: true, RulePackPath: packs}
+
+	// Directory packs are used in place
+	path, err := cache.FetchRulePack(source)
+	if err != nil || path != filepath.Join(packs, "main") {
+		t.Errorf("FetchRulePack(main) = %s, %v, want the main pack", path, err)
+	}
+
+	// Archived packs are extracted into the cache
+	source.Ref = "v1.0.0"
+	path, err = cache.FetchRulePack(source)
+	if err != nil {
+		t.Fatalf("FetchRulePack(v1.0.0) error = %v", err)
+	}
+	if !strings.HasPrefix(path, cache.dir) {
+		t.Errorf("FetchRulePack(v1.0.0) = %s, want a pack in the cache", path)
+	}
+	if _, err := os.Stat(filepath.Join(path, "terraform/aws/s3/s3_v0.rego")); err != nil {
+		t.Errorf("FetchRulePack(v1.0.0) did not extract the policies: %v", err)
+	}
+	if again, err := cache.FetchRulePack(source); err != nil || again != path {
+		t.Errorf("FetchRulePack(v1.0.0) again = %s, %v, want the extracted %s", again, err, path)
+	}
+
+	// Commits are matched by SHA prefix
+	source.Ref = "0123456"
+	if path, err := cache.FetchRulePack(source); err != nil || path != filepath.Join(packs, "main") {
+		t.Errorf("FetchRulePack(0123456) = %s, %v, want the main pack", path, err)
+	}
+
+	// A single pack can be passed directly
+	source.Ref = "main"
+	source.RulePackPath = filepath.Join(packs, "main")
+	if path, err := cache.FetchRulePack(source); err != nil || path != source.RulePackPath {
+		t.Errorf("FetchRulePack(single pack) = %s, %v, want %s", path, err, source.RulePackPath)
+	}
+}
+
+func TestFetchRulePackErrors(t *testing.T) {
+	cache, err := OpenPACCache(PACCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	packs := t.TempDir()
+	writeFiles(t, filepath.Join(packs, "main"), map[string]string{
+		RulePackManifestFile: rulePackManifest(rulePackCommit, "main"),
+	})
+
+	source := PACSource{Repository: rulePackRepository, Ref: "v2.0.0", Offline: true, RulePackPath: packs}
+	_, err = cache.FetchRulePack(source)
+	var fetchErr *PACFetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("FetchRulePack(v2.0.0) error = %v, want a PACFetchError", err)
+	}
+	if !strings.Contains(err.Error(), `pac_version "v2.0.0"`) || !strings.Contains(err.Error(), rulePackCommit+",main") {
+		t.Errorf("FetchRulePack(v2.0.0) error = %v, want the missing version and the available packs", err)
+	}
+
+	source.Ref = "main"
+	source.Repository = "https://github.com/example/other"
+	if _, err := cache.FetchRulePack(source); err == nil {
+		t.Errorf("FetchRulePack() accepted a pack of another repository")
+	}
+
+	source.RulePackPath = ""
+	if _, err := cache.FetchRulePack(source); err == nil {
+		t.Errorf("FetchRulePack() without a rule pack path succeeded")
+	}
+
+	traversal := filepath.Join(t.TempDir(), "evil.tar.gz")
+	writeRulePackArchive(t, traversal, map[string]string{
+		RulePackManifestFile: rulePackManifest(rulePackCommit, "main"),
+		"../escaped.rego":    "package rules.escaped\n",
+	})
+	source = PACSource{Repository: rulePackRepository, Offline: true, RulePackPath: traversal}
+	if _, err := cache.FetchRulePack(source); err == nil || !strings.Contains(err.Error(), "escapes") {
+		t.Errorf("FetchRulePack(traversal) error = %v, want an escaping entry to be rejected", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(traversal), "escaped.rego")); err == nil {
+		t.Errorf("FetchRulePack(traversal) wrote outside the rule pack")
+	}
+}
@@ -0,0 +1,276 @@
+package utils
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Terraform providers with their own taxons and policy folder in the default taxonomy.
+const (
+	CloudAWS    = "aws"
+	CloudAzure  = "azurerm"
+	CloudGoogle = "google"
+)
+
+// TaxonomyVersion is the version of the taxonomy file format read by the provider.
+const TaxonomyVersion = 1
+
+// RulePackTaxonomyFiles are the names of the taxonomy file shipped at the root
+// of a rule pack, replacing the embedded default taxonomy.
+var RulePackTaxonomyFiles = []string{"taxonomy.yaml", "taxonomy.yml", "taxonomy.json"}
+
+//go:embed taxonomy.yaml
+var defaultTaxonomy []byte
+
+// Taxonomy maps the resource types of every cloud to the taxon directories
+// holding their policies. It is read from YAML or JSON.
+type Taxonomy struct {
+	Version int                      `json:"version"`
+	Clouds  map[string]CloudTaxonomy `json:"clouds"`
+
+	// resourceClouds indexes the clouds by the resource types they map
+	resourceClouds map[string]string
+}
+
+// CloudTaxonomy maps the resource types of a cloud, named after the Terraform
+// provider whose resource types start with it, e.g. azurerm.
+type CloudTaxonomy struct {
+	// Subdirectory is the directory of the PAC repository holding the policies of the cloud.
+	Subdirectory string `json:"subdirectory"`
+	// Services maps services to the taxon directory holding their policies.
+	Services map[string]string `json:"services"`
+	// Resources maps resource types to their service.
+	Resources map[string]string `json:"resources"`
+}
+
+// TaxonomyError is returned when a taxonomy file cannot be read.
+type TaxonomyError struct {
+	File string
+	// Override is set for the user supplied taxonomy file, unset for the one of the rule pack.
+	Override bool
+	Err      error
+}
+
+func (e *TaxonomyError) Error() string {
+	return fmt.Sprintf("invalid taxonomy %s: %v", e.File, e.Err)
+}
+
+func (e *TaxonomyError) Unwrap() error {
+	return e.Err
+}
+
+// parseTaxonomy reads a YAML or JSON taxonomy.
+func parseTaxonomy(content []byte) (*Taxonomy, error) {
+	taxonomy := &Taxonomy{}
+	if err := yaml.UnmarshalStrict(content, taxonomy); err != nil {
+		return nil, err
+	}
+	switch {
+	case taxonomy.Version == 0:
+		return nil, fmt.Errorf("version is missing, want %d", TaxonomyVersion)
+	case taxonomy.Version > TaxonomyVersion:
+		return nil, fmt.Errorf("version %d is newer than the supported version %d, upgrade the provider", taxonomy.Version, TaxonomyVersion)
+	}
+	if err := taxonomy.index(); err != nil {
+		return nil, err
+	}
+	return taxonomy, nil
+}
+
+// index maps every resource type to its cloud. A type may only be mapped by one cloud.
+func (t *Taxonomy) index() error {
+	t.resourceClouds = map[string]string{}
+	for _, cloud := range SortedKeys(t.Clouds) {
+		for resourceType := range t.Clouds[cloud].Resources {
+			if other, ok := t.resourceClouds[resourceType]; ok {
+				return fmt.Errorf("resource type %s is mapped by clouds %s and %s", resourceType, other, cloud)
+			}
+			t.resourceClouds[resourceType] = cloud
+		}
+	}
+	return nil
+}
+
+// merge adds the clouds, services and resource types of override, replacing
+// the entries they share. A resource type moves to the cloud of the override.
+func (t *Taxonomy) merge(override *Taxonomy) error {
+	for cloudName, cloud := range override.Clouds {
+		for resourceType := range cloud.Resources {
+			if other, ok := t.resourceClouds[resourceType]; ok {
+				delete(t.Clouds[other].Resources, resourceType)
+			}
+		}
+
+		merged, ok := t.Clouds[cloudName]
+		if !ok {
+			merged = CloudTaxonomy{}
+		}
+		if cloud.Subdirectory != "" {
+			merged.Subdirectory = cloud.Subdirectory
+		}
+		merged.Services = mergeMaps(merged.Services, cloud.Services)
+		merged.Resources = mergeMaps(merged.Resources, cloud.Resources)
+		t.Clouds[cloudName] = merged
+	}
+	return t.index()
+}
+
+func mergeMaps(base, override map[string]string) map[string]string {
+	merged := map[string]string{}
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
+
+// SortedKeys returns the keys of a map in ascending order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadTaxonomy reads the taxonomy shipped with the pack, the embedded default
+// when it has none, and merges the override file over it.
+func loadTaxonomy(packPath, overrideFile string) (*Taxonomy, string, error) {
+	taxonomy, packFile, err := loadPackTaxonomy(packPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if overrideFile == "" {
+		return taxonomy, packFile, nil
+	}
+
+	content, err := os.ReadFile(overrideFile)
+	if err != nil {
+		return nil, "", &TaxonomyError{File: overrideFile, Override: true, Err: err}
+	}
+	override, err := parseTaxonomy(content)
+	if err != nil {
+		return nil, "", &TaxonomyError{File: overrideFile, Override: true, Err: err}
+	}
+	if err := taxonomy.merge(override); err != nil {
+		return nil, "", &TaxonomyError{File: overrideFile, Override: true, Err: err}
+	}
+	return taxonomy, packFile, nil
+}
+
+// loadPackTaxonomy reads the taxonomy file of the pack and returns its path,
+// or the embedded default taxonomy and an empty path.
+func loadPackTaxonomy(packPath string) (*Taxonomy, string, error) {
+	for _, name := range RulePackTaxonomyFiles {
+		file := filepath.Join(packPath, name)
+		content, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, "", &TaxonomyError{File: file, Err: err}
+		}
+		taxonomy, err := parseTaxonomy(content)
+		if err != nil {
+			return nil, "", &TaxonomyError{File: file, Err: err}
+		}
+		return taxonomy, file, nil
+	}
+	taxonomy, err := DefaultTaxonomy()
+	return taxonomy, "", err
+}
+
+// DefaultTaxonomy returns the taxonomy embedded in the provider.
+func DefaultTaxonomy() (*Taxonomy, error) {
+	taxonomy, err := parseTaxonomy(defaultTaxonomy)
+	if err != nil {
+		return nil, &TaxonomyError{File: "embedded taxonomy.yaml", Err: err}
+	}
+	return taxonomy, nil
+}
+
+// cloudOf returns the cloud of a resource type: the cloud mapping it, or the
+// cloud named after its provider.
+func (t *Taxonomy) cloudOf(resourceType string) (string, bool) {
+	if cloud, ok := t.resourceClouds[resourceType]; ok {
+		return cloud, true
+	}
+	provider := strings.SplitN(resourceType, "_", 2)[0]
+	_, ok := t.Clouds[provider]
+	return provider, ok
+}
+
+// IsCloudResource reports whether the resource type belongs to a cloud of the
+// taxonomy, i.e. a resource policies are expected for.
+func (t *Taxonomy) IsCloudResource(resourceType string) bool {
+	_, ok := t.cloudOf(resourceType)
+	return ok
+}
+
+// taxon returns the cloud and the taxon of a resource type, false when the
+// type or its service is not mapped.
+func (t *Taxonomy) taxon(resourceType string) (string, string, bool) {
+	cloud, ok := t.resourceClouds[resourceType]
+	if !ok {
+		return "", "", false
+	}
+	service := t.Clouds[cloud].Resources[resourceType]
+	taxon, ok := t.Clouds[cloud].Services[service]
+	return cloud, taxon, ok && taxon != ""
+}
+
+// subdirectory returns the directory of the PAC repository holding the
+// policies of cloud, terraform/<cloud> when the taxonomy sets none.
+func (t *Taxonomy) subdirectory(cloud string) string {
+	if subdirectory := t.Clouds[cloud].Subdirectory; subdirectory != "" {
+		return subdirectory
+	}
+	return path.Join("terraform", cloud)
+}
+
+// unmappedResourceTypes returns the sorted cloud resource types without a taxon.
+func (t *Taxonomy) unmappedResourceTypes(resources []string) []string {
+	unmapped := []string{}
+	for _, resource := range resources {
+		if !t.IsCloudResource(resource) {
+			continue
+		}
+		if _, _, ok := t.taxon(resource); !ok {
+			unmapped = append(unmapped, resource)
+		}
+	}
+	sort.Strings(unmapped)
+	return unmapped
+}
+
+// cloudTaxons returns the sorted taxons of the resource types by cloud.
+// Resource types of unknown clouds or services are ignored.
+func (t *Taxonomy) cloudTaxons(resources []string) map[string][]string {
+	found := map[string]map[string]struct{}{}
+	for _, resource := range resources {
+		cloud, taxon, ok := t.taxon(resource)
+		if !ok {
+			continue
+		}
+		if found[cloud] == nil {
+			found[cloud] = map[string]struct{}{}
+		}
+		found[cloud][taxon] = struct{}{}
+	}
+
+	result := map[string][]string{}
+	for cloud, taxons := range found {
+		result[cloud] = SortedKeys(taxons)
+	}
+	return result
+}
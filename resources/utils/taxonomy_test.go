@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultTaxonomyCloudTaxons(t *testing.T) {
+	taxonomy, err := DefaultTaxonomy()
+	if err != nil {
+		t.Fatalf("DefaultTaxonomy() error = %v", err)
+	}
+	got := taxonomy.cloudTaxons([]string{
+		"aws_instance",
+		"azurerm_storage_account",
+		"azurerm_storage_container",
+		"azurerm_key_vault",
+		"google_storage_bucket",
+		"google_unknown_resource",
+		"kubernetes_namespace",
+	})
+	want := map[string][]string{
+		CloudAWS:    {"Amazon Elastic Compute Cloud (EC2)"},
+		CloudAzure:  {"Azure Key Vault", "Azure Storage"},
+		CloudGoogle: {"Google Cloud Storage"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cloudTaxons() = %v, want %v", got, want)
+	}
+}
+
+func TestDefaultTaxonomyComplete(t *testing.T) {
+	taxonomy, err := DefaultTaxonomy()
+	if err != nil {
+		t.Fatalf("DefaultTaxonomy() error = %v", err)
+	}
+	for cloudName, cloud := range taxonomy.Clouds {
+		for resource, service := range cloud.Resources {
+			if !strings.HasPrefix(resource, cloudName+"_") {
+				t.Errorf("%s is mapped as a %s resource", resource, cloudName)
+			}
+			if cloudName != CloudAWS {
+				// the aws mapping predates the check and lists services without taxons
+				if _, ok := cloud.Services[service]; !ok {
+					t.Errorf("service %s of %s has no taxon", service, resource)
+				}
+			}
+		}
+	}
+}
+
+func TestLoadTaxonomy(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pack/taxonomy.json": `{
+  "version": 1,
+  "clouds": {
+    "aws": {
+      "subdirectory": "policies/aws",
+      "services": {"EC2": "compute", "S3": "storage"},
+      "resources": {"aws_instance": "EC2", "aws_s3_bucket": "S3"}
+    }
+  }
+}`,
+		"override.yaml": `
+version: 1
+clouds:
+  aws:
+    services:
+      S3: object-storage
+    resources:
+      acme_bucket: S3
+  acme:
+    subdirectory: internal/acme
+    services:
+      Widgets: widgets
+    resources:
+      acme_widget: Widgets
+`,
+		"newer.yaml":   "version: 2\nclouds: {}\n",
+		"unknown.yaml": "version: 1\nclouds:\n  aws:\n    resource_types: {}\n",
+		"twice.yaml":   "version: 1\nclouds:\n  aws:\n    resources: {acme_widget: S3}\n  acme:\n    resources: {acme_widget: Widgets}\n",
+	})
+	packPath := filepath.Join(dir, "pack")
+
+	taxonomy, packFile, err := loadTaxonomy(packPath, filepath.Join(dir, "override.yaml"))
+	if err != nil {
+		t.Fatalf("loadTaxonomy() error = %v", err)
+	}
+	if packFile != filepath.Join(packPath, "taxonomy.json") {
+		t.Errorf("loadTaxonomy() pack file = %s, want the taxonomy.json of the pack", packFile)
+	}
+	got := taxonomy.cloudTaxons([]string{"aws_instance", "aws_s3_bucket", "acme_bucket", "acme_widget", "aws_vpc"})
+	want := map[string][]string{
+		CloudAWS: {"compute", "object-storage"},
+		"acme":   {"widgets"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cloudTaxons() = %v, want %v", got, want)
+	}
+	if got := taxonomy.unmappedResourceTypes([]string{"aws_vpc", "acme_gadget", "random_id"}); !reflect.DeepEqual(got, []string{"acme_gadget", "aws_vpc"}) {
+		t.Errorf("unmappedResourceTypes() = %v", got)
+	}
+	if got := taxonomy.subdirectory("acme"); got != "internal/acme" {
+		t.Errorf("subdirectory(acme) = %s, want internal/acme", got)
+	}
+
+	// Packs without a taxonomy use the embedded default
+	taxonomy, packFile, err = loadTaxonomy(dir+"/missing", "")
+	if err != nil || packFile != "" || taxonomy.subdirectory(CloudGoogle) != "terraform/gcp" {
+		t.Errorf("loadTaxonomy(without pack taxonomy) = %s, %v, want the default taxonomy", packFile, err)
+	}
+
+	for _, name := range []string{"newer.yaml", "unknown.yaml", "twice.yaml", "missing.yaml"} {
+		_, _, err := loadTaxonomy(packPath, filepath.Join(dir, name))
+		var taxonomyErr *TaxonomyError
+		if !errors.As(err, &taxonomyErr) || !taxonomyErr.Override {
+			t.Errorf("loadTaxonomy(%s) error = %v, want an override TaxonomyError", name, err)
+		}
+	}
+}
+
+func TestGetDefaultPACMultiCloud(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	fixture.commit(t, map[string]string{
+		"terraform/azure/Azure Storage/storage_v1.rego":              "package rules.azure_storage_v1\n",
+		"terraform/azure/Azure Key Vault/key_vault_v1.rego":          "package rules.azure_key_vault_v1\n",
+		"terraform/gcp/Google Cloud Storage/gcs_v1.rego":             "package rules.gcs_v1\n",
+		"terraform/gcp/Google Compute Engine/compute_engine_v1.rego": "package rules.compute_engine_v1\n",
+	})
+	iacPath := t.TempDir()
+	writeFiles(t, iacPath, map[string]string{
+		"main.tf": `
+resource "aws_instance" "web" {}
+resource "azurerm_storage_account" "logs" {}
+resource "google_storage_bucket" "assets" {}
+resource "google_sql_database_instance" "db" {}
+resource "google_tags_tag_key" "env" {}
+resource "random_id" "suffix" {}
+`,
+	})
+
+	pacPath, coverage, cleanup, err := GetDefaultPAC(mustLoadIaC(t, iacPath), PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}})
+	if err != nil {
+		t.Fatalf("GetDefaultPAC() error = %v", err)
+	}
+	defer cleanup()
+
+	got := extractedFiles(t, pacPath)
+	want := []string{
+		"terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego",
+		"terraform/azure/Azure Storage/storage_v1.rego",
+		"terraform/gcp/Google Cloud Storage/gcs_v1.rego",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDefaultPAC() extracted %v, want %v", got, want)
+	}
+	if want := []string{"google_tags_tag_key"}; !reflect.DeepEqual(coverage.UnmappedResourceTypes, want) {
+		t.Errorf("GetDefaultPAC() unmapped resource types = %v, want %v", coverage.UnmappedResourceTypes, want)
+	}
+	if want := []string{"terraform/gcp/Google Cloud SQL"}; !reflect.DeepEqual(coverage.MissingTaxons, want) {
+		t.Errorf("GetDefaultPAC() missing taxons = %v, want %v", coverage.MissingTaxons, want)
+	}
+
+	// Clouds without a policy folder are skipped
+	azureOnly := t.TempDir()
+	writeFiles(t, azureOnly, map[string]string{"main.tf": `resource "azurerm_key_vault" "secrets" {}`})
+	source := PACSource{Repository: fixture.url, Ref: "v1.0.0", Cache: PACCacheOptions{Dir: t.TempDir()}}
+	pacPath, coverage, cleanup, err = GetDefaultPAC(mustLoadIaC(t, azureOnly), source)
+	if err != nil {
+		t.Fatalf("GetDefaultPAC(without azure folder) error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(pacPath, "terraform/azure/Azure Key Vault/key_vault_v1.rego")); err == nil {
+		t.Errorf("GetDefaultPAC(v1.0.0) extracted rules of a later commit")
+	}
+	if want := []string{"terraform/azure/Azure Key Vault"}; !reflect.DeepEqual(coverage.MissingTaxons, want) {
+		t.Errorf("GetDefaultPAC(v1.0.0) missing taxons = %v, want %v", coverage.MissingTaxons, want)
+	}
+}
+
+func TestGetDefaultPACPackTaxonomy(t *testing.T) {
+	fixture := newPACRepoFixture(t)
+	fixture.commit(t, map[string]string{
+		"taxonomy.yaml": `
+version: 1
+clouds:
+  aws:
+    subdirectory: terraform/aws
+    services:
+      EC2: compute
+    resources:
+      aws_instance: EC2
+`,
+		"terraform/aws/compute/instance_v1.rego": "package rules.instance_v1\n",
+	})
+	iacPath := t.TempDir()
+	writeFiles(t, iacPath, map[string]string{
+		"main.tf":       "resource \"aws_instance\" \"web\" {}\nresource \"acme_widget\" \"w\" {}\n",
+		"override.json": `{"version": 1, "clouds": {"aws": {"resources": {"acme_widget": "EC2"}}}}`,
+	})
+	source := PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}, TaxonomyFile: filepath.Join(iacPath, "override.json")}
+
+	pacPath, coverage, cleanup, err := GetDefaultPAC(mustLoadIaC(t, iacPath), source)
+	if err != nil {
+		t.Fatalf("GetDefaultPAC() error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(pacPath, "terraform/aws/compute/instance_v1.rego")); err != nil {
+		t.Errorf("GetDefaultPAC() did not extract the rules of the pack taxonomy: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pacPath, "terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego")); err == nil {
+		t.Errorf("GetDefaultPAC() extracted the rules of the default taxonomy")
+	}
+	if !coverage.IsCloudResource("acme_widget") || len(coverage.UnmappedResourceTypes) != 0 {
+		t.Errorf("GetDefaultPAC() coverage = %+v, want the overridden acme_widget covered", coverage)
+	}
+
+	// The taxonomy selects the policies and must be listed in the checksum file
+	sum := sha256Hex("package rules.instance_v1\n")
+	fixture.commit(t, map[string]string{"checksums.sha256": sum + "  terraform/aws/compute/instance_v1.rego\n"})
+	source.Verification = PACVerification{ChecksumFile: "checksums.sha256"}
+	source.Cache = PACCacheOptions{Dir: t.TempDir()}
+	_, _, _, err = GetDefaultPAC(mustLoadIaC(t, iacPath), source)
+	var verifyErr *PACVerificationError
+	if !errors.As(err, &verifyErr) || !strings.Contains(err.Error(), "taxonomy.yaml is not listed") {
+		t.Errorf("GetDefaultPAC(unlisted taxonomy) error = %v, want a verification error", err)
+	}
+}
@@ -0,0 +1,972 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// TerraformResource is a managed resource instance declared in Terraform configuration.
+type TerraformResource struct {
+	// Address is the resource instance address, e.g. module.vpc.aws_subnet.private[0]
+	Address  string
+	Type     string
+	Name     string
+	Provider string
+	Filepath string
+	// StartLine and EndLine are the lines of the resource block in Filepath.
+	StartLine int
+	EndLine   int
+	// Attributes holds the statically evaluated arguments and nested blocks.
+	// Values that cannot be resolved without running terraform are nil.
+	Attributes map[string]interface{}
+}
+
+// maxModuleDepth guards against module calls that recurse into each other.
+const maxModuleDepth = 32
+
+var terraformRootSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+		{Type: "output", LabelNames: []string{"name"}},
+	},
+}
+
+var terraformOutputSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value"},
+	},
+}
+
+var terraformVariableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "default"},
+	},
+}
+
+// resourceMetaArguments are handled by terraform itself and are not part of the resource.
+var resourceMetaArguments = map[string]bool{
+	"count":       true,
+	"for_each":    true,
+	"provider":    true,
+	"depends_on":  true,
+	"lifecycle":   true,
+	"provisioner": true,
+	"connection":  true,
+}
+
+// terraformFunctions are the terraform functions available when evaluating expressions.
+// Calls to any other function evaluate to an unknown value.
+var terraformFunctions = map[string]function.Function{
+	"abs":             stdlib.AbsoluteFunc,
+	"ceil":            stdlib.CeilFunc,
+	"chomp":           stdlib.ChompFunc,
+	"chunklist":       stdlib.ChunklistFunc,
+	"coalesce":        stdlib.CoalesceFunc,
+	"coalescelist":    stdlib.CoalesceListFunc,
+	"compact":         stdlib.CompactFunc,
+	"concat":          stdlib.ConcatFunc,
+	"contains":        stdlib.ContainsFunc,
+	"distinct":        stdlib.DistinctFunc,
+	"element":         stdlib.ElementFunc,
+	"flatten":         stdlib.FlattenFunc,
+	"floor":           stdlib.FloorFunc,
+	"format":          stdlib.FormatFunc,
+	"formatlist":      stdlib.FormatListFunc,
+	"indent":          stdlib.IndentFunc,
+	"join":            stdlib.JoinFunc,
+	"jsondecode":      stdlib.JSONDecodeFunc,
+	"jsonencode":      stdlib.JSONEncodeFunc,
+	"keys":            stdlib.KeysFunc,
+	"length":          stdlib.LengthFunc,
+	"log":             stdlib.LogFunc,
+	"lookup":          stdlib.LookupFunc,
+	"lower":           stdlib.LowerFunc,
+	"max":             stdlib.MaxFunc,
+	"merge":           stdlib.MergeFunc,
+	"min":             stdlib.MinFunc,
+	"parseint":        stdlib.ParseIntFunc,
+	"pow":             stdlib.PowFunc,
+	"range":           stdlib.RangeFunc,
+	"regex":           stdlib.RegexFunc,
+	"regexall":        stdlib.RegexAllFunc,
+	"replace":         stdlib.ReplaceFunc,
+	"reverse":         stdlib.ReverseListFunc,
+	"setintersection": stdlib.SetIntersectionFunc,
+	"setproduct":      stdlib.SetProductFunc,
+	"setsubtract":     stdlib.SetSubtractFunc,
+	"setunion":        stdlib.SetUnionFunc,
+	"signum":          stdlib.SignumFunc,
+	"slice":           stdlib.SliceFunc,
+	"sort":            stdlib.SortFunc,
+	"split":           stdlib.SplitFunc,
+	"strrev":          stdlib.ReverseFunc,
+	"substr":          stdlib.SubstrFunc,
+	"title":           stdlib.TitleFunc,
+	"tolist":          stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":           stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":        stdlib.MakeToFunc(cty.Number),
+	"toset":           stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":        stdlib.MakeToFunc(cty.String),
+	"trim":            stdlib.TrimFunc,
+	"trimprefix":      stdlib.TrimPrefixFunc,
+	"trimspace":       stdlib.TrimSpaceFunc,
+	"trimsuffix":      stdlib.TrimSuffixFunc,
+	"upper":           stdlib.UpperFunc,
+	"values":          stdlib.ValuesFunc,
+	"zipmap":          stdlib.ZipmapFunc,
+}
+
+// terraformModule holds the blocks of a single module directory.
+type terraformModule struct {
+	dir         string
+	variables   map[string]hcl.Expression
+	locals      hcl.Attributes
+	outputs     map[string]hcl.Expression
+	resources   []*hcl.Block
+	dataSources []*hcl.Block
+	moduleCalls []*hcl.Block
+}
+
+// resourceInstance is a single instance of an expanded resource block.
+type resourceInstance struct {
+	address string
+	// variables are count or each, depending on how the block was expanded
+	variables map[string]cty.Value
+}
+
+type terraformLoader struct {
+	parser  *hclparse.Parser
+	rootDir string
+	// moduleDirs are the directories terraform init installed the module
+	// calls of the root module into, by module key, e.g. vpc.subnets
+	moduleDirs map[string]string
+	// rootFiles restricts the root module to these files instead of every
+	// Terraform file of rootDir
+	rootFiles []string
+}
+
+// moduleManifestPath is the manifest terraform init writes into the root module.
+var moduleManifestPath = filepath.Join(".terraform", "modules", "modules.json")
+
+// moduleManifest is the module manifest written by terraform init.
+type moduleManifest struct {
+	Modules []struct {
+		// Key is the path of module call names from the root module, joined by dots
+		Key    string `json:"Key"`
+		Source string `json:"Source"`
+		// Dir is the installed module, relative to the root module
+		Dir string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// setRoot prepares loading the root module in dir and reads the modules
+// installed by terraform init, if it ran.
+func (l *terraformLoader) setRoot(dir string) error {
+	l.rootDir = dir
+	l.moduleDirs = map[string]string{}
+
+	content, err := os.ReadFile(filepath.Join(dir, moduleManifestPath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return &IaCParseError{Problems: []SourceProblem{{File: filepath.Join(dir, moduleManifestPath), Message: err.Error()}}}
+	}
+	manifest := moduleManifest{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return &IaCParseError{Problems: []SourceProblem{{File: filepath.Join(dir, moduleManifestPath), Message: err.Error()}}}
+	}
+	for _, module := range manifest.Modules {
+		if module.Key == "" || module.Dir == "" {
+			continue
+		}
+		moduleDir := filepath.FromSlash(module.Dir)
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(dir, moduleDir)
+		}
+		l.moduleDirs[module.Key] = moduleDir
+	}
+	return nil
+}
+
+// isTerraformFile reports whether name is a Terraform configuration file in
+// the native or the JSON syntax.
+func isTerraformFile(name string) bool {
+	return strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")
+}
+
+// parseFile parses a native or JSON syntax file, depending on its extension.
+func (l *terraformLoader) parseFile(file string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(file, ".json") {
+		return l.parser.ParseJSONFile(file)
+	}
+	return l.parser.ParseHCLFile(file)
+}
+
+// LoadTerraform loads the managed resources of the root module in dir and of
+// every module it calls, evaluating expressions as far as they can be
+// resolved without running terraform. Local module sources are followed even
+// outside dir, registry and remote ones once terraform init installed them.
+// dir may also be a single .tf or .tf.json file, loaded as the root module
+// with the variables and installed modules of its directory.
+func LoadTerraform(dir string) ([]TerraformResource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: err.Error()}}}
+	}
+
+	loader := &terraformLoader{parser: hclparse.NewParser()}
+	if !info.IsDir() {
+		if !isTerraformFile(dir) {
+			return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: "not a directory or .tf or .tf.json file"}}}
+		}
+		loader.rootFiles = []string{dir}
+		dir = filepath.Dir(dir)
+	}
+	if err := loader.setRoot(dir); err != nil {
+		return nil, err
+	}
+	inputs, err := loader.rootVariables(dir)
+	if err != nil {
+		return nil, err
+	}
+	resources, _, err := loader.loadModule(dir, "", inputs, 0)
+	return resources, err
+}
+
+// DiscoverTerraform loads the managed resources of every Terraform module
+// below dir, e.g. a repository holding several root modules. Modules called by
+// another module are only loaded through their call, so their resources keep
+// the module address. Hidden directories such as .terraform are skipped.
+func DiscoverTerraform(dir string) ([]TerraformResource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: err.Error()}}}
+	}
+	if !info.IsDir() {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: "not a directory"}}}
+	}
+
+	moduleDirs := []string{}
+	seen := map[string]bool{}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if moduleDir := filepath.Dir(path); isTerraformFile(entry.Name()) && !seen[moduleDir] {
+			seen[moduleDir] = true
+			moduleDirs = append(moduleDirs, moduleDir)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: err.Error()}}}
+	}
+
+	loader := &terraformLoader{parser: hclparse.NewParser()}
+	called := map[string]bool{}
+	for _, moduleDir := range moduleDirs {
+		module, err := loader.parseModule(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range module.moduleCalls {
+			if childDir, ok := loader.moduleSource(moduleDir, block); ok {
+				called[filepath.Clean(childDir)] = true
+			}
+		}
+	}
+
+	resources := []TerraformResource{}
+	for _, moduleDir := range moduleDirs {
+		if called[filepath.Clean(moduleDir)] {
+			continue
+		}
+		if err := loader.setRoot(moduleDir); err != nil {
+			return nil, err
+		}
+		inputs, err := loader.rootVariables(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		moduleResources, _, err := loader.loadModule(moduleDir, "", inputs, 0)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, moduleResources...)
+	}
+	return resources, nil
+}
+
+// rootVariables reads terraform.tfvars and *.auto.tfvars of the root module,
+// in the native or the JSON syntax.
+func (l *terraformLoader) rootVariables(dir string) (map[string]cty.Value, error) {
+	files := []string{filepath.Join(dir, "terraform.tfvars"), filepath.Join(dir, "terraform.tfvars.json")}
+	for _, pattern := range []string{"*.auto.tfvars", "*.auto.tfvars.json"} {
+		autoFiles, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(autoFiles)
+		files = append(files, autoFiles...)
+	}
+
+	inputs := map[string]cty.Value{}
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		hclFile, diags := l.parseFile(file)
+		if diags.HasErrors() {
+			return nil, newIaCParseError(diags)
+		}
+		attrs, diags := hclFile.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, newIaCParseError(diags)
+		}
+		for name, attr := range attrs {
+			value, _ := attr.Expr.Value(nil)
+			inputs[name] = value
+		}
+	}
+	return inputs, nil
+}
+
+func (l *terraformLoader) parseModule(dir string) (*terraformModule, error) {
+	files, err := l.moduleFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	module := &terraformModule{
+		dir:       dir,
+		variables: map[string]hcl.Expression{},
+		locals:    hcl.Attributes{},
+		outputs:   map[string]hcl.Expression{},
+	}
+	for _, file := range files {
+		hclFile, diags := l.parseFile(file)
+		if diags.HasErrors() {
+			return nil, newIaCParseError(diags)
+		}
+		content, _, diags := hclFile.Body.PartialContent(terraformRootSchema)
+		if diags.HasErrors() {
+			return nil, newIaCParseError(diags)
+		}
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "resource":
+				module.resources = append(module.resources, block)
+			case "data":
+				module.dataSources = append(module.dataSources, block)
+			case "module":
+				module.moduleCalls = append(module.moduleCalls, block)
+			case "variable":
+				variable, _, _ := block.Body.PartialContent(terraformVariableSchema)
+				module.variables[block.Labels[0]] = nil
+				if def, ok := variable.Attributes["default"]; ok {
+					module.variables[block.Labels[0]] = def.Expr
+				}
+			case "output":
+				output, _, _ := block.Body.PartialContent(terraformOutputSchema)
+				if value, ok := output.Attributes["value"]; ok {
+					module.outputs[block.Labels[0]] = value.Expr
+				}
+			case "locals":
+				attrs, _ := block.Body.JustAttributes()
+				for name, attr := range attrs {
+					module.locals[name] = attr
+				}
+			}
+		}
+	}
+	return module, nil
+}
+
+// moduleFiles returns the Terraform files of the module in dir.
+func (l *terraformLoader) moduleFiles(dir string) ([]string, error) {
+	if dir == l.rootDir && len(l.rootFiles) > 0 {
+		return l.rootFiles, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: dir, Message: err.Error()}}}
+	}
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && isTerraformFile(entry.Name()) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+func (l *terraformLoader) loadModule(dir, prefix string, inputs map[string]cty.Value, depth int) ([]TerraformResource, cty.Value, error) {
+	if depth > maxModuleDepth {
+		return nil, cty.NilVal, fmt.Errorf("module calls nested deeper than %d levels at %s", maxModuleDepth, dir)
+	}
+
+	module, err := l.parseModule(dir)
+	if err != nil {
+		return nil, cty.NilVal, err
+	}
+
+	variables := map[string]cty.Value{}
+	for name, def := range module.variables {
+		if value, ok := inputs[name]; ok {
+			variables[name] = value
+		} else if def != nil {
+			variables[name], _ = def.Value(nil)
+		} else {
+			variables[name] = cty.DynamicVal
+		}
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(variables),
+			"path": cty.ObjectVal(map[string]cty.Value{
+				"module": cty.StringVal(dir),
+				"root":   cty.StringVal(l.rootDir),
+				"cwd":    cty.StringVal(l.rootDir),
+			}),
+			"terraform": cty.ObjectVal(map[string]cty.Value{
+				"workspace": cty.StringVal("default"),
+			}),
+		},
+		Functions: terraformFunctions,
+	}
+
+	dataSources := map[string]map[string]cty.Value{}
+	for _, block := range module.dataSources {
+		if dataSources[block.Labels[0]] == nil {
+			dataSources[block.Labels[0]] = map[string]cty.Value{}
+		}
+		dataSources[block.Labels[0]][block.Labels[1]] = cty.DynamicVal
+	}
+	ctx.Variables["data"] = objectOfObjects(dataSources)
+
+	moduleOutputs := map[string]cty.Value{}
+	for _, block := range module.moduleCalls {
+		moduleOutputs[block.Labels[0]] = cty.DynamicVal
+	}
+
+	// Outputs of called modules feed back into the arguments of this module,
+	// so evaluate everything a second time once they are known.
+	passes := 1
+	if len(module.moduleCalls) > 0 {
+		passes = 2
+	}
+
+	references := collectReferences(module.resources)
+	var resources []TerraformResource
+	for pass := 0; pass < passes; pass++ {
+		ctx.Variables["module"] = cty.ObjectVal(moduleOutputs)
+		ctx.Variables["local"] = evalLocals(module.locals, ctx)
+
+		// The first evaluation resolves arguments without references to other resources.
+		// The second resolves references to the arguments found by the first,
+		// references to computed attributes resolve to the resource address.
+		firstPass := l.evalResources(module, prefix, ctx)
+		for resourceType, byName := range resourceReferences(module, prefix, firstPass, references) {
+			ctx.Variables[resourceType] = byName
+		}
+		ctx.Variables["local"] = evalLocals(module.locals, ctx)
+		resources = l.evalResources(module, prefix, ctx)
+
+		for _, block := range module.moduleCalls {
+			childDir, ok := l.moduleSource(module.dir, block)
+			if !ok {
+				childDir, ok = l.moduleDirs[moduleKey(prefix, block.Labels[0])]
+			}
+			if !ok {
+				// registry and remote modules are unknown until terraform init ran
+				continue
+			}
+			attrs, _ := block.Body.JustAttributes()
+			childInputs := map[string]cty.Value{}
+			for name, attr := range attrs {
+				if name == "source" || name == "version" || resourceMetaArguments[name] || name == "providers" {
+					continue
+				}
+				childInputs[name], _ = attr.Expr.Value(ctx)
+			}
+			childResources, childOutputs, err := l.loadModule(childDir, prefix+"module."+block.Labels[0]+".", childInputs, depth+1)
+			if err != nil {
+				return nil, cty.NilVal, err
+			}
+			resources = append(resources, childResources...)
+			moduleOutputs[block.Labels[0]] = childOutputs
+		}
+	}
+
+	outputs := map[string]cty.Value{}
+	for name, expr := range module.outputs {
+		outputs[name], _ = expr.Value(ctx)
+	}
+	return resources, cty.ObjectVal(outputs), nil
+}
+
+// moduleSource resolves the directory of a module call with a local source.
+func (l *terraformLoader) moduleSource(dir string, block *hcl.Block) (string, bool) {
+	attrs, _ := block.Body.JustAttributes()
+	source, ok := attrs["source"]
+	if !ok {
+		return "", false
+	}
+	value, diags := source.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", false
+	}
+	sourcePath := value.AsString()
+	if !strings.HasPrefix(sourcePath, "./") && !strings.HasPrefix(sourcePath, "../") {
+		return "", false
+	}
+	return filepath.Join(dir, sourcePath), true
+}
+
+// moduleKey returns the key of a module call in the module manifest, the
+// module call names from the root module joined by dots.
+func moduleKey(prefix, name string) string {
+	key := strings.ReplaceAll(prefix, "module.", "")
+	return key + name
+}
+
+func evalLocals(locals hcl.Attributes, ctx *hcl.EvalContext) cty.Value {
+	values := map[string]cty.Value{}
+	for name := range locals {
+		values[name] = cty.DynamicVal
+	}
+
+	// Locals may reference each other, evaluate until nothing changes
+	for pass := 0; pass <= len(locals); pass++ {
+		ctx.Variables["local"] = cty.ObjectVal(values)
+		changed := false
+		for name, attr := range locals {
+			if values[name].IsWhollyKnown() {
+				continue
+			}
+			value, diags := attr.Expr.Value(ctx)
+			if !diags.HasErrors() && value.IsWhollyKnown() {
+				values[name] = value
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return cty.ObjectVal(values)
+}
+
+// bodyAttributes returns the arguments of a native or JSON syntax body. Without
+// a schema the nested blocks of JSON bodies are returned as arguments too.
+func bodyAttributes(body hcl.Body) hcl.Attributes {
+	if syntaxBody, ok := body.(*hclsyntax.Body); ok {
+		attrs := hcl.Attributes{}
+		for name, attr := range syntaxBody.Attributes {
+			attrs[name] = attr.AsHCLAttribute()
+		}
+		return attrs
+	}
+	attrs, _ := body.JustAttributes()
+	return attrs
+}
+
+// blockLines returns the first and last line of a block.
+func blockLines(block *hcl.Block) (int, int) {
+	start, end := block.DefRange.Start.Line, block.DefRange.End.Line
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		return start, body.SrcRange.End.Line
+	}
+	// The missing item range of a JSON body is the closing brace of its object
+	if closing := block.Body.MissingItemRange(); closing.Filename == block.DefRange.Filename && closing.End.Line > end {
+		end = closing.End.Line
+	}
+	return start, end
+}
+
+// instances expands a resource block by its count or for_each argument.
+func instances(block *hcl.Block, prefix string, ctx *hcl.EvalContext) []resourceInstance {
+	address := prefix + block.Labels[0] + "." + block.Labels[1]
+	attrs := bodyAttributes(block.Body)
+
+	if attr, ok := attrs["count"]; ok {
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.Number {
+			return []resourceInstance{{address: address}}
+		}
+		count, _ := value.AsBigFloat().Int64()
+		result := []resourceInstance{}
+		for i := int64(0); i < count; i++ {
+			result = append(result, resourceInstance{
+				address: fmt.Sprintf("%s[%d]", address, i),
+				variables: map[string]cty.Value{
+					"count": cty.ObjectVal(map[string]cty.Value{"index": cty.NumberIntVal(i)}),
+				},
+			})
+		}
+		return result
+	}
+
+	if attr, ok := attrs["for_each"]; ok {
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+			return []resourceInstance{{address: address}}
+		}
+		result := []resourceInstance{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			if value.Type().IsSetType() {
+				key = element
+			}
+			if key.Type() != cty.String {
+				continue
+			}
+			result = append(result, resourceInstance{
+				address: fmt.Sprintf("%s[%q]", address, key.AsString()),
+				variables: map[string]cty.Value{
+					"each": cty.ObjectVal(map[string]cty.Value{"key": key, "value": element}),
+				},
+			})
+		}
+		return result
+	}
+
+	return []resourceInstance{{address: address}}
+}
+
+func (l *terraformLoader) evalResources(module *terraformModule, prefix string, ctx *hcl.EvalContext) []TerraformResource {
+	resources := []TerraformResource{}
+	for _, block := range module.resources {
+		startLine, endLine := blockLines(block)
+		for _, instance := range instances(block, prefix, ctx) {
+			instanceCtx := ctx.NewChild()
+			instanceCtx.Variables = instance.variables
+
+			var attributes map[string]interface{}
+			if body, ok := block.Body.(*hclsyntax.Body); ok {
+				attributes = evalBody(body, instanceCtx)
+			} else {
+				attributes = evalJSONBody(block.Body, instanceCtx)
+			}
+			resources = append(resources, TerraformResource{
+				Address:    instance.address,
+				Type:       block.Labels[0],
+				Name:       block.Labels[1],
+				Provider:   strings.SplitN(block.Labels[0], "_", 2)[0],
+				Filepath:   block.DefRange.Filename,
+				StartLine:  startLine,
+				EndLine:    endLine,
+				Attributes: attributes,
+			})
+		}
+	}
+	return resources
+}
+
+// evalJSONBody evaluates a JSON syntax body. Nested blocks are indistinguishable
+// from arguments without the provider schema and evaluate to objects or lists of objects.
+func evalJSONBody(body hcl.Body, ctx *hcl.EvalContext) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for name, attr := range bodyAttributes(body) {
+		if resourceMetaArguments[name] {
+			continue
+		}
+		value, _ := attr.Expr.Value(ctx)
+		attributes[name] = ctyToInterface(value)
+	}
+	return attributes
+}
+
+func evalBody(body *hclsyntax.Body, ctx *hcl.EvalContext) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for name, attr := range body.Attributes {
+		if resourceMetaArguments[name] {
+			continue
+		}
+		value, _ := attr.Expr.Value(ctx)
+		attributes[name] = ctyToInterface(value)
+	}
+
+	for _, block := range body.Blocks {
+		if resourceMetaArguments[block.Type] {
+			continue
+		}
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			for _, content := range evalDynamicBlock(block, ctx) {
+				attributes[block.Labels[0]] = append(blockList(attributes[block.Labels[0]]), content)
+			}
+			continue
+		}
+		attributes[block.Type] = append(blockList(attributes[block.Type]), evalBody(block.Body, ctx))
+	}
+	return attributes
+}
+
+// evalDynamicBlock expands a dynamic block whose for_each is statically known.
+func evalDynamicBlock(block *hclsyntax.Block, ctx *hcl.EvalContext) []interface{} {
+	forEach, ok := block.Body.Attributes["for_each"]
+	if !ok {
+		return nil
+	}
+	value, diags := forEach.Expr.Value(ctx)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+		return nil
+	}
+
+	iterator := block.Labels[0]
+	if attr, ok := block.Body.Attributes["iterator"]; ok {
+		if name := hcl.ExprAsKeyword(attr.Expr); name != "" {
+			iterator = name
+		}
+	}
+
+	contents := []interface{}{}
+	for it := value.ElementIterator(); it.Next(); {
+		key, element := it.Element()
+		for _, content := range block.Body.Blocks {
+			if content.Type != "content" {
+				continue
+			}
+			contentCtx := ctx.NewChild()
+			contentCtx.Variables = map[string]cty.Value{
+				iterator: cty.ObjectVal(map[string]cty.Value{"key": key, "value": element}),
+			}
+			contents = append(contents, evalBody(content.Body, contentCtx))
+		}
+	}
+	return contents
+}
+
+func blockList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{}
+}
+
+// resourceReference identifies an attribute of a resource referenced by an expression.
+type resourceReference struct {
+	resourceType string
+	name         string
+	attribute    string
+}
+
+// collectReferences finds the resource attributes referenced by the resource blocks.
+func collectReferences(blocks []*hcl.Block) []resourceReference {
+	traversals := []hcl.Traversal{}
+	for _, block := range blocks {
+		body, ok := block.Body.(*hclsyntax.Body)
+		if !ok {
+			for _, attr := range bodyAttributes(block.Body) {
+				traversals = append(traversals, attr.Expr.Variables()...)
+			}
+			continue
+		}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+				traversals = append(traversals, expr.Traversal)
+			}
+			return nil
+		})
+	}
+
+	references := []resourceReference{}
+	for _, traversal := range traversals {
+		reference := resourceReference{resourceType: traversal.RootName()}
+		for _, step := range traversal[1:] {
+			if attr, ok := step.(hcl.TraverseAttr); ok {
+				if reference.name == "" {
+					reference.name = attr.Name
+				} else {
+					reference.attribute = attr.Name
+					break
+				}
+			}
+		}
+		if reference.name != "" && reference.attribute != "" {
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+// resourceReferences builds the values resource references evaluate to, keyed by resource type.
+func resourceReferences(module *terraformModule, prefix string, resources []TerraformResource, references []resourceReference) map[string]cty.Value {
+	referenced := map[string]map[string]bool{}
+	for _, reference := range references {
+		key := reference.resourceType + "." + reference.name
+		if referenced[key] == nil {
+			referenced[key] = map[string]bool{}
+		}
+		referenced[key][reference.attribute] = true
+	}
+
+	byAddress := map[string]TerraformResource{}
+	for _, resource := range resources {
+		byAddress[resource.Address] = resource
+	}
+
+	values := map[string]map[string]cty.Value{}
+	for _, block := range module.resources {
+		resourceType, name := block.Labels[0], block.Labels[1]
+		address := prefix + resourceType + "." + name
+		attributes := referenced[resourceType+"."+name]
+
+		instanceValue := func(instanceAddress string) cty.Value {
+			object := map[string]cty.Value{"id": cty.StringVal(instanceAddress)}
+			resource := byAddress[instanceAddress]
+			for attribute := range attributes {
+				if value, ok := resource.Attributes[attribute]; ok && value != nil {
+					object[attribute] = interfaceToCty(value)
+				} else if attribute != "id" {
+					object[attribute] = cty.StringVal(instanceAddress)
+				}
+			}
+			return cty.ObjectVal(object)
+		}
+
+		var value cty.Value
+		if _, ok := byAddress[address]; ok {
+			value = instanceValue(address)
+		} else {
+			// count and for_each instances are referenced by index or key
+			counted := []cty.Value{}
+			keyed := map[string]cty.Value{}
+			for _, resource := range resources {
+				if !strings.HasPrefix(resource.Address, address+"[") {
+					continue
+				}
+				index := strings.TrimSuffix(strings.TrimPrefix(resource.Address, address+"["), "]")
+				if strings.HasPrefix(index, `"`) {
+					var key string
+					if err := json.Unmarshal([]byte(index), &key); err == nil {
+						keyed[key] = instanceValue(resource.Address)
+					}
+				} else {
+					counted = append(counted, instanceValue(resource.Address))
+				}
+			}
+			switch {
+			case len(keyed) > 0:
+				value = cty.ObjectVal(keyed)
+			case len(counted) > 0:
+				value = cty.TupleVal(counted)
+			default:
+				value = cty.EmptyTupleVal
+			}
+		}
+
+		if values[resourceType] == nil {
+			values[resourceType] = map[string]cty.Value{}
+		}
+		values[resourceType][name] = value
+	}
+
+	result := map[string]cty.Value{}
+	for resourceType, byName := range values {
+		result[resourceType] = cty.ObjectVal(byName)
+	}
+	return result
+}
+
+func objectOfObjects(values map[string]map[string]cty.Value) cty.Value {
+	result := map[string]cty.Value{}
+	for key, object := range values {
+		result[key] = cty.ObjectVal(object)
+	}
+	return cty.ObjectVal(result)
+}
+
+// ctyToInterface converts a value into its JSON compatible representation,
+// unknown and null values become nil.
+func ctyToInterface(value cty.Value) interface{} {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	valueType := value.Type()
+	switch {
+	case valueType == cty.String:
+		return value.AsString()
+	case valueType == cty.Bool:
+		return value.True()
+	case valueType == cty.Number:
+		return json.Number(value.AsBigFloat().Text('f', -1))
+	case valueType.IsListType() || valueType.IsSetType() || valueType.IsTupleType():
+		list := []interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			list = append(list, ctyToInterface(element))
+		}
+		return list
+	case valueType.IsMapType() || valueType.IsObjectType():
+		object := map[string]interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			object[key.AsString()] = ctyToInterface(element)
+		}
+		return object
+	}
+	return nil
+}
+
+// interfaceToCty converts a value produced by ctyToInterface back into a cty value.
+func interfaceToCty(value interface{}) cty.Value {
+	switch v := value.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	case string:
+		return cty.StringVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	case json.Number:
+		number, err := cty.ParseNumberVal(string(v))
+		if err != nil {
+			return cty.DynamicVal
+		}
+		return number
+	case []interface{}:
+		if len(v) == 0 {
+			return cty.EmptyTupleVal
+		}
+		elements := make([]cty.Value, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, interfaceToCty(element))
+		}
+		return cty.TupleVal(elements)
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return cty.EmptyObjectVal
+		}
+		attributes := map[string]cty.Value{}
+		for key, element := range v {
+			attributes[key] = interfaceToCty(element)
+		}
+		return cty.ObjectVal(attributes)
+	}
+	return cty.DynamicVal
+}
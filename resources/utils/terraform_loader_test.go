@@ -0,0 +1,315 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// mustLoadIaC loads the resources of the IaC at path or fails the test.
+func mustLoadIaC(t *testing.T, path string) []TerraformResource {
+	t.Helper()
+	resources, err := LoadIaC(path)
+	if err != nil {
+		t.Fatalf("LoadIaC(%s) error = %v", path, err)
+	}
+	return resources
+}
+
+func TestLoadTerraform(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tf": `
+variable "env" {
+  default = "dev"
+}
+
+locals {
+  name = "app-${var.env}"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = local.name
+  tags = {
+    Environment = var.env
+  }
+}
+
+resource "aws_s3_bucket_versioning" "logs" {
+  bucket = aws_s3_bucket.logs.id
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_subnet" "private" {
+  count      = 2
+  cidr_block = "10.0.${count.index}.0/24"
+}
+
+module "network" {
+  source = "./modules/network"
+  name   = local.name
+}
+
+resource "aws_instance" "web" {
+  subnet_id = module.network.vpc_id
+}
+`,
+		"terraform.tfvars": `env = "prod"`,
+		"modules/network/main.tf": `
+variable "name" {}
+
+resource "aws_vpc" "main" {
+  for_each   = toset(["a"])
+  cidr_block = "10.0.0.0/16"
+  tags = {
+    Name = "${var.name}-${each.key}"
+  }
+}
+
+output "vpc_id" {
+  value = aws_vpc.main["a"].id
+}
+`,
+	})
+
+	resources, err := LoadTerraform(dir)
+	if err != nil {
+		t.Fatalf("LoadTerraform() error = %v", err)
+	}
+
+	byAddress := map[string]TerraformResource{}
+	for _, resource := range resources {
+		byAddress[resource.Address] = resource
+	}
+
+	tests := []struct {
+		address   string
+		attribute string
+		want      interface{}
+	}{
+		{"aws_s3_bucket.logs", "bucket", "app-prod"},
+		{"aws_s3_bucket.logs", "tags", map[string]interface{}{"Environment": "prod"}},
+		{"aws_s3_bucket_versioning.logs", "bucket", "aws_s3_bucket.logs"},
+		{"aws_s3_bucket_versioning.logs", "versioning_configuration", []interface{}{map[string]interface{}{"status": "Enabled"}}},
+		{"aws_subnet.private[1]", "cidr_block", "10.0.1.0/24"},
+		{`module.network.aws_vpc.main["a"]`, "tags", map[string]interface{}{"Name": "app-prod-a"}},
+		{"aws_instance.web", "subnet_id", `module.network.aws_vpc.main["a"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address+"."+tt.attribute, func(t *testing.T) {
+			resource, ok := byAddress[tt.address]
+			if !ok {
+				t.Fatalf("LoadTerraform() is missing %s, got %v", tt.address, reflect.ValueOf(byAddress).MapKeys())
+			}
+			if got := resource.Attributes[tt.attribute]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s.%s = %#v, want %#v", tt.address, tt.attribute, got, tt.want)
+			}
+		})
+	}
+
+	if got := byAddress["aws_subnet.private[0]"].Filepath; got != filepath.Join(dir, "main.tf") {
+		t.Errorf("aws_subnet.private[0] filepath = %s", got)
+	}
+	if _, err := json.Marshal(byAddress["aws_subnet.private[0]"].Attributes); err != nil {
+		t.Errorf("attributes are not JSON compatible: %v", err)
+	}
+}
+
+func TestLoadTerraformInvalid(t *testing.T) {
+	if _, err := LoadTerraform(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("LoadTerraform() of a missing directory did not fail")
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.tf": `resource "aws_s3_bucket" {`})
+	if _, err := LoadTerraform(dir); err == nil {
+		t.Errorf("LoadTerraform() of invalid HCL did not fail")
+	}
+}
+
+func TestDiscoverTerraform(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tf": `
+# resource "aws_iam_user" "commented" {}
+/*
+resource "aws_iam_role" "commented" {}
+*/
+resource "aws_instance" "web" {
+  count     = 2
+  user_data = <<-EOT
+    resource "aws_db_instance" "heredoc" {}
+  EOT
+}
+
+module "network" {
+  source = "./modules/network"
+}
+`,
+		"buckets.tf.json": `{
+  "resource": {
+    "aws_s3_bucket": {
+      "logs": {
+        "for_each": {"a": "logs-a", "b": "logs-b"},
+        "bucket": "${each.value}"
+      }
+    }
+  }
+}`,
+		"modules/network/main.tf": `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+`,
+		"stacks/dns/main.tf": `
+resource "aws_route53_zone" "main" {
+  name = "example.com"
+}
+`,
+		".terraform/modules/cached/main.tf": `
+resource "aws_kms_key" "cached" {}
+`,
+	})
+
+	resources, err := DiscoverTerraform(dir)
+	if err != nil {
+		t.Fatalf("DiscoverTerraform() error = %v", err)
+	}
+	byAddress := map[string]TerraformResource{}
+	for _, resource := range resources {
+		if _, ok := byAddress[resource.Address]; ok {
+			t.Errorf("DiscoverTerraform() returned %s twice", resource.Address)
+		}
+		byAddress[resource.Address] = resource
+	}
+
+	want := map[string][3]interface{}{
+		"aws_instance.web[0]":         {"main.tf", 6, 11},
+		"aws_instance.web[1]":         {"main.tf", 6, 11},
+		`aws_s3_bucket.logs["a"]`:     {"buckets.tf.json", 4, 7},
+		`aws_s3_bucket.logs["b"]`:     {"buckets.tf.json", 4, 7},
+		"module.network.aws_vpc.main": {"modules/network/main.tf", 2, 4},
+		"aws_route53_zone.main":       {"stacks/dns/main.tf", 2, 4},
+	}
+	if len(byAddress) != len(want) {
+		t.Errorf("DiscoverTerraform() = %v, want %d resources", reflect.ValueOf(byAddress).MapKeys(), len(want))
+	}
+	for address, location := range want {
+		resource, ok := byAddress[address]
+		if !ok {
+			t.Errorf("DiscoverTerraform() is missing %s", address)
+			continue
+		}
+		if resource.Filepath != filepath.Join(dir, location[0].(string)) || resource.StartLine != location[1] || resource.EndLine != location[2] {
+			t.Errorf("%s is at %s:%d-%d, want %s:%d-%d", address,
+				resource.Filepath, resource.StartLine, resource.EndLine, location[0], location[1], location[2])
+		}
+	}
+	if got := byAddress[`aws_s3_bucket.logs["b"]`].Attributes["bucket"]; got != "logs-b" {
+		t.Errorf(`aws_s3_bucket.logs["b"].bucket = %v, want logs-b`, got)
+	}
+
+	types := resourceTypes(resources)
+	sort.Strings(types)
+	wantTypes := []string{"aws_instance", "aws_route53_zone", "aws_s3_bucket", "aws_vpc"}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Errorf("resourceTypes() = %v, want %v", types, wantTypes)
+	}
+}
+
+func TestDiscoverTerraformModuleCalls(t *testing.T) {
+	workspace := t.TempDir()
+	dir := filepath.Join(workspace, "app")
+	writeFiles(t, workspace, map[string]string{
+		"app/main.tf": `
+module "vpc" {
+  source = "../shared/vpc"
+}
+
+module "cluster" {
+  source  = "terraform-aws-modules/eks/aws"
+  version = "20.0.0"
+}
+
+module "not_installed" {
+  source = "terraform-aws-modules/rds/aws"
+}
+`,
+		"shared/vpc/main.tf": `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+`,
+		"app/.terraform/modules/modules.json": `{"Modules":[
+  {"Key":"","Source":"","Dir":"."},
+  {"Key":"vpc","Source":"../shared/vpc","Dir":"../shared/vpc"},
+  {"Key":"cluster","Source":"registry.terraform.io/terraform-aws-modules/eks/aws","Version":"20.0.0","Dir":".terraform/modules/cluster"},
+  {"Key":"cluster.nodes","Source":"./modules/nodes","Dir":".terraform/modules/cluster/modules/nodes"}
+]}`,
+		"app/.terraform/modules/cluster/main.tf": `
+resource "aws_eks_cluster" "this" {
+  name = "cluster"
+}
+
+module "nodes" {
+  source = "./modules/nodes"
+}
+`,
+		"app/.terraform/modules/cluster/modules/nodes/main.tf": `
+resource "aws_instance" "node" {
+  instance_type = "t3.large"
+}
+`,
+	})
+
+	resources, err := DiscoverTerraform(dir)
+	if err != nil {
+		t.Fatalf("DiscoverTerraform() error = %v", err)
+	}
+	addresses := []string{}
+	for _, resource := range resources {
+		addresses = append(addresses, resource.Address)
+	}
+	sort.Strings(addresses)
+	want := []string{
+		"module.cluster.aws_eks_cluster.this",
+		"module.cluster.module.nodes.aws_instance.node",
+		"module.vpc.aws_vpc.main",
+	}
+	if !reflect.DeepEqual(addresses, want) {
+		t.Errorf("DiscoverTerraform() = %v, want %v", addresses, want)
+	}
+
+	fixture := newPACRepoFixture(t)
+	pacPath, _, cleanup, err := GetDefaultPAC(mustLoadIaC(t, dir), PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}})
+	if err != nil {
+		t.Fatalf("GetDefaultPAC() error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(pacPath, "terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego")); err != nil {
+		t.Errorf("GetDefaultPAC() did not extract the EC2 rules of the installed module: %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{".terraform/modules/modules.json": "{"})
+	if _, err := DiscoverTerraform(dir); err == nil {
+		t.Errorf("DiscoverTerraform() accepted a corrupt module manifest")
+	}
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// terraformPlan is the part of the output of terraform show -json read by the scan.
+type terraformPlan struct {
+	FormatVersion string `json:"format_version"`
+	PlannedValues *struct {
+		RootModule terraformPlanModule `json:"root_module"`
+	} `json:"planned_values"`
+}
+
+// terraformPlanModule holds the planned resources of a module and its child modules.
+type terraformPlanModule struct {
+	Resources    []terraformPlanResource `json:"resources"`
+	ChildModules []terraformPlanModule   `json:"child_modules"`
+}
+
+type terraformPlanResource struct {
+	// Address is the full resource instance address, e.g. module.vpc.aws_subnet.private["a"]
+	Address string `json:"address"`
+	// Mode is managed for resources and data for data sources
+	Mode         string                 `json:"mode"`
+	Type         string                 `json:"type"`
+	Name         string                 `json:"name"`
+	ProviderName string                 `json:"provider_name"`
+	Values       map[string]interface{} `json:"values"`
+}
+
+// LoadTerraformPlan loads the managed resources planned in the JSON output of
+// terraform show -json, with variables, count, for_each and modules resolved
+// by terraform. Values only known after apply are nil.
+func LoadTerraformPlan(path string) ([]TerraformResource, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: path, Message: err.Error()}}, Plan: true}
+	}
+
+	// Numbers are kept as json.Number like the values of the HCL loader
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	plan := terraformPlan{}
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: path, Message: "invalid plan JSON: " + err.Error()}}, Plan: true}
+	}
+	if plan.FormatVersion == "" || plan.PlannedValues == nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{
+			File:    path,
+			Message: "not a Terraform plan, create it with terraform show -json",
+		}}, Plan: true}
+	}
+
+	resources := []TerraformResource{}
+	var loadModule func(module terraformPlanModule)
+	loadModule = func(module terraformPlanModule) {
+		for _, planned := range module.Resources {
+			if planned.Mode != "managed" {
+				continue
+			}
+			attributes := planned.Values
+			if attributes == nil {
+				attributes = map[string]interface{}{}
+			}
+			resources = append(resources, TerraformResource{
+				Address:    planned.Address,
+				Type:       planned.Type,
+				Name:       planned.Name,
+				Provider:   planProvider(planned),
+				Filepath:   path,
+				Attributes: attributes,
+			})
+		}
+		for _, child := range module.ChildModules {
+			loadModule(child)
+		}
+	}
+	loadModule(plan.PlannedValues.RootModule)
+	return resources, nil
+}
+
+// planProvider returns the short provider name, e.g. aws for registry.terraform.io/hashicorp/aws.
+func planProvider(resource terraformPlanResource) string {
+	if resource.ProviderName == "" {
+		return strings.SplitN(resource.Type, "_", 2)[0]
+	}
+	parts := strings.Split(resource.ProviderName, "/")
+	return parts[len(parts)-1]
+}
+
+// IsTerraformPlan reports whether path names a plan JSON file rather than a
+// Terraform configuration file in the JSON syntax.
+func IsTerraformPlan(path string) bool {
+	return strings.HasSuffix(path, ".json") && !isTerraformFile(path)
+}
+
+// LoadIaC loads the resources of a Terraform configuration directory, see
+// DiscoverTerraform, of a .tf or .tf.json file, see LoadTerraform, or of a
+// plan JSON file, see LoadTerraformPlan.
+func LoadIaC(path string) ([]TerraformResource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, &IaCParseError{Problems: []SourceProblem{{File: path, Message: err.Error()}}}
+	}
+	switch {
+	case info.IsDir():
+		return DiscoverTerraform(path)
+	case isTerraformFile(path):
+		return LoadTerraform(path)
+	case IsTerraformPlan(path):
+		return LoadTerraformPlan(path)
+	}
+	return nil, &IaCParseError{Problems: []SourceProblem{{File: path, Message: "not a Terraform configuration directory, .tf or .tf.json file or plan JSON file"}}}
+}
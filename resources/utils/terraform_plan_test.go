@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const testPlanJSON = `{
+  "format_version": "1.2",
+  "terraform_version": "1.9.0",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "provider_name": "registry.terraform.io/hashicorp/aws",
+          "values": {"instance_type": "t3.micro", "monitoring": true, "root_block_device": [{"volume_size": 20}]}
+        },
+        {
+          "address": "data.aws_ami.ubuntu",
+          "mode": "data",
+          "type": "aws_ami",
+          "name": "ubuntu",
+          "provider_name": "registry.terraform.io/hashicorp/aws",
+          "values": {}
+        }
+      ],
+      "child_modules": [
+        {
+          "address": "module.vpc",
+          "resources": [
+            {
+              "address": "module.vpc.aws_subnet.private[\"a\"]",
+              "mode": "managed",
+              "type": "aws_subnet",
+              "name": "private",
+              "index": "a",
+              "provider_name": "registry.terraform.io/hashicorp/aws",
+              "values": {"cidr_block": "10.0.1.0/24", "map_public_ip_on_launch": false}
+            },
+            {
+              "address": "module.vpc.aws_subnet.private[\"b\"]",
+              "mode": "managed",
+              "type": "aws_subnet",
+              "name": "private",
+              "index": "b",
+              "provider_name": "registry.terraform.io/hashicorp/aws",
+              "values": {"cidr_block": "10.0.2.0/24"}
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestLoadTerraformPlan(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"plan.json": testPlanJSON})
+	planPath := filepath.Join(dir, "plan.json")
+
+	resources, err := LoadIaC(planPath)
+	if err != nil {
+		t.Fatalf("LoadIaC() error = %v", err)
+	}
+	byAddress := map[string]TerraformResource{}
+	for _, resource := range resources {
+		byAddress[resource.Address] = resource
+	}
+	if len(byAddress) != 3 {
+		t.Errorf("LoadIaC() = %v, want the 3 managed resources", reflect.ValueOf(byAddress).MapKeys())
+	}
+
+	subnet, ok := byAddress[`module.vpc.aws_subnet.private["a"]`]
+	if !ok {
+		t.Fatalf(`LoadIaC() is missing module.vpc.aws_subnet.private["a"]`)
+	}
+	if subnet.Type != "aws_subnet" || subnet.Provider != "aws" || subnet.Filepath != planPath {
+		t.Errorf("subnet = %+v", subnet)
+	}
+	if subnet.Attributes["cidr_block"] != "10.0.1.0/24" || subnet.Attributes["map_public_ip_on_launch"] != false {
+		t.Errorf("subnet attributes = %v", subnet.Attributes)
+	}
+	want := []interface{}{map[string]interface{}{"volume_size": json.Number("20")}}
+	if got := byAddress["aws_instance.web"].Attributes["root_block_device"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("aws_instance.web root_block_device = %#v, want %#v", got, want)
+	}
+
+	if types := resourceTypes(resources); len(types) != 2 {
+		t.Errorf("resourceTypes() = %v, want aws_instance and aws_subnet", types)
+	}
+}
+
+func TestLoadTerraformPlanInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"state.json":  `{"version": 4, "resources": []}`,
+		"broken.json": `{"format_version": `,
+	})
+	for _, name := range []string{"state.json", "broken.json", "missing.json"} {
+		_, err := LoadIaC(filepath.Join(dir, name))
+		var parseErr *IaCParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("LoadIaC(%s) error = %v, want an IaCParseError", name, err)
+		}
+	}
+}
+
+func TestLoadIaCFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tf":      `resource "aws_s3_bucket" "main" {}`,
+		"other.tf":     `resource "aws_s3_bucket" "other" {}`,
+		"json.tf.json": `{"resource": {"aws_sqs_queue": {"json": {}}}}`,
+		"plan.json":    testPlanJSON,
+		"notes.txt":    `resource "aws_s3_bucket" "notes" {}`,
+	})
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"main.tf", []string{"aws_s3_bucket.main"}},
+		{"json.tf.json", []string{"aws_sqs_queue.json"}},
+		{"plan.json", []string{"aws_instance.web", `module.vpc.aws_subnet.private["a"]`, `module.vpc.aws_subnet.private["b"]`}},
+	}
+	for _, tt := range tests {
+		resources, err := LoadIaC(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Errorf("LoadIaC(%s) error = %v", tt.file, err)
+			continue
+		}
+		got := []string{}
+		for _, resource := range resources {
+			got = append(got, resource.Address)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LoadIaC(%s) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+
+	var parseErr *IaCParseError
+	if _, err := LoadIaC(filepath.Join(dir, "notes.txt")); !errors.As(err, &parseErr) {
+		t.Errorf("LoadIaC(notes.txt) error = %v, want an IaCParseError", err)
+	}
+}
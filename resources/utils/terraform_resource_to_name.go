@@ -1,1393 +0,0 @@
-package utils
-
-var TerraformResourceToTerraformName = map[string]string{
-	"aws_vpn_connection":                                               "VPN (Site-to-Site)",
-	"aws_wafregional_rule":                                             "WAF Classic Regional",
-	"aws_ram_sharing_with_organization":                                "RAM (Resource Access Manager)",
-	"aws_appintegrations_event_integration":                            "AppIntegrations",
-	"aws_cognito_user_in_group":                                        "Cognito IDP (Identity Provider)",
-	"aws_internetmonitor_monitor":                                      "CloudWatch Internet Monitor",
-	"aws_waf_ipset":                                                    "WAF Classic",
-	"aws_api_gateway_vpc_link":                                         "API Gateway",
-	"aws_iam_signing_certificate":                                      "IAM (Identity & Access Management)",
-	"aws_sns_platform_application":                                     "SNS (Simple Notification)",
-	"aws_storagegateway_upload_buffer":                                 "Storage Gateway",
-	"aws_verifiedpermissions_policy":                                   "Verified Permissions",
-	"aws_vpc_endpoint_service_private_dns_verification":                "VPC (Virtual Private Cloud)",
-	"aws_elastic_beanstalk_environment":                                "Elastic Beanstalk",
-	"aws_iam_policy_attachment":                                        "IAM (Identity & Access Management)",
-	"aws_iot_logging_options":                                          "IoT Core",
-	"aws_kendra_index":                                                 "Kendra",
-	"aws_rds_certificate":                                              "RDS (Relational Database)",
-	"aws_ssoadmin_permission_set_inline_policy":                        "SSO Admin",
-	"aws_wafregional_rule_group":                                       "WAF Classic Regional",
-	"aws_appstream_fleet":                                              "AppStream 2.0",
-	"aws_cloudwatch_metric_alarm":                                      "CloudWatch",
-	"aws_dms_certificate":                                              "DMS (Database Migration)",
-	"aws_globalaccelerator_custom_routing_endpoint_group":              "Global Accelerator",
-	"aws_glue_classifier":                                              "Glue",
-	"aws_inspector_resource_group":                                     "Inspector Classic",
-	"aws_batch_compute_environment":                                    "Batch",
-	"aws_launch_configuration":                                         "Auto Scaling",
-	"aws_s3control_bucket":                                             "S3 Control",
-	"aws_sesv2_dedicated_ip_assignment":                                "SESv2 (Simple Email V2)",
-	"aws_detective_organization_admin_account":                         "Detective",
-	"aws_vpc_ipam_organization_admin_account":                          "VPC IPAM (IP Address Manager)",
-	"aws_dms_s3_endpoint":                                              "DMS (Database Migration)",
-	"aws_emrserverless_application":                                    "EMR Serverless",
-	"aws_iot_thing_group":                                              "IoT Core",
-	"aws_appintegrations_data_integration":                             "AppIntegrations",
-	"aws_backup_plan":                                                  "Backup",
-	"aws_connect_hours_of_operation":                                   "Connect",
-	"aws_sagemaker_data_quality_job_definition":                        "SageMaker",
-	"aws_sesv2_email_identity":                                         "SESv2 (Simple Email V2)",
-	"aws_api_gateway_domain_name":                                      "API Gateway",
-	"aws_ssm_maintenance_window_task":                                  "SSM (Systems Manager)",
-	"aws_ssm_service_setting":                                          "SSM (Systems Manager)",
-	"aws_glue_partition_index":                                         "Glue",
-	"aws_efs_backup_policy":                                            "EFS (Elastic File System)",
-	"aws_kinesis_resource_policy":                                      "Kinesis",
-	"aws_servicecatalog_organizations_access":                          "Service Catalog",
-	"aws_sns_topic_data_protection_policy":                             "SNS (Simple Notification)",
-	"aws_dynamodb_table_replica":                                       "DynamoDB",
-	"aws_dms_endpoint":                                                 "DMS (Database Migration)",
-	"aws_elasticache_user_group":                                       "ElastiCache",
-	"aws_elastic_beanstalk_application_version":                        "Elastic Beanstalk",
-	"aws_fis_experiment_template":                                      "FIS (Fault Injection Simulator)",
-	"aws_lambda_event_source_mapping":                                  "Lambda",
-	"aws_lex_slot_type":                                                "Lex Model Building",
-	"aws_redshift_subnet_group":                                        "Redshift",
-	"aws_apprunner_vpc_connector":                                      "App Runner",
-	"aws_storagegateway_cache":                                         "Storage Gateway",
-	"aws_connect_instance_storage_config":                              "Connect",
-	"aws_load_balancer_listener_policy":                                "ELB Classic",
-	"aws_msk_vpc_connection":                                           "Managed Streaming for Kafka",
-	"aws_sagemaker_endpoint_configuration":                             "SageMaker",
-	"aws_ami_launch_permission":                                        "EC2 (Elastic Compute Cloud)",
-	"aws_medialive_channel":                                            "Elemental MediaLive",
-	"aws_route53_cidr_location":                                        "Route 53",
-	"aws_vpc_ipam":                                                     "VPC IPAM (IP Address Manager)",
-	"aws_chimesdkmediapipelines_media_insights_pipeline_configuration": "Chime SDK Media Pipelines",
-	"aws_backup_selection":                                             "Backup",
-	"aws_glacier_vault_lock":                                           "S3 Glacier",
-	"aws_neptune_cluster_instance":                                     "Neptune",
-	"aws_redshiftserverless_namespace":                                 "Redshift Serverless",
-	"aws_securityhub_finding_aggregator":                               "Security Hub",
-	"aws_wafregional_size_constraint_set":                              "WAF Classic Regional",
-	"aws_wafv2_rule_group":                                             "WAF",
-	"aws_api_gateway_documentation_part":                               "API Gateway",
-	"aws_lb":                                                           "ELB (Elastic Load Balancing)",
-	"aws_dax_subnet_group":                                             "DynamoDB Accelerator (DAX)",
-	"aws_appstream_fleet_stack_association":                            "AppStream 2.0",
-	"aws_devicefarm_upload":                                            "Device Farm",
-	"aws_pinpoint_baidu_channel":                                       "Pinpoint",
-	"aws_sagemaker_image":                                              "SageMaker",
-	"aws_appmesh_gateway_route":                                        "App Mesh",
-	"aws_cloudsearch_domain_service_access_policy":                     "CloudSearch",
-	"aws_lightsail_container_service":                                  "Lightsail",
-	"aws_vpc_endpoint_route_table_association":                         "VPC (Virtual Private Cloud)",
-	"aws_bedrockagent_data_source":                                     "Bedrock Agents",
-	"aws_m2_application":                                               "Mainframe Modernization",
-	"aws_opensearch_package":                                           "OpenSearch",
-	"aws_qldb_stream":                                                  "QLDB (Quantum Ledger Database)",
-	"aws_rolesanywhere_trust_anchor":                                   "Roles Anywhere",
-	"aws_cognito_identity_provider":                                    "Cognito IDP (Identity Provider)",
-	"aws_eks_fargate_profile":                                          "EKS (Elastic Kubernetes)",
-	"aws_opsworks_haproxy_layer":                                       "OpsWorks",
-	"aws_paymentcryptography_key":                                      "Payment Cryptography Control Plane",
-	"aws_route53_cidr_collection":                                      "Route 53",
-	"aws_vpc_peering_connection_accepter":                              "VPC (Virtual Private Cloud)",
-	"aws_appsync_domain_name":                                          "AppSync",
-	"aws_config_organization_custom_rule":                              "Config",
-	"aws_pinpoint_adm_channel":                                         "Pinpoint",
-	"aws_s3_bucket_intelligent_tiering_configuration":                  "S3 (Simple Storage)",
-	"aws_appconfig_configuration_profile":                              "AppConfig",
-	"aws_sagemaker_app":                                                "SageMaker",
-	"aws_scheduler_schedule_group":                                     "EventBridge Scheduler",
-	"aws_servicecatalog_product_portfolio_association":                 "Service Catalog",
-	"aws_quicksight_account_subscription":                              "QuickSight",
-	"aws_neptune_subnet_group":                                         "Neptune",
-	"aws_redshift_usage_limit":                                         "Redshift",
-	"aws_securityhub_invite_accepter":                                  "Security Hub",
-	"aws_db_subnet_group":                                              "RDS (Relational Database)",
-	"aws_ec2_carrier_gateway":                                          "Wavelength",
-	"aws_iam_access_key":                                               "IAM (Identity & Access Management)",
-	"aws_kinesis_stream_consumer":                                      "Kinesis",
-	"aws_lakeformation_resource_lf_tag":                                "Lake Formation",
-	"aws_location_place_index":                                         "Location",
-	"aws_connect_contact_flow":                                         "Connect",
-	"aws_ec2_transit_gateway":                                          "Transit Gateway",
-	"aws_grafana_role_association":                                     "Managed Grafana",
-	"aws_opensearchserverless_collection":                              "OpenSearch Serverless",
-	"aws_verifiedaccess_group":                                         "Verified Access",
-	"aws_appsync_api_cache":                                            "AppSync",
-	"aws_finspace_kx_environment":                                      "FinSpace",
-	"aws_guardduty_malware_protection_plan":                            "GuardDuty",
-	"aws_iam_user_policy":                                              "IAM (Identity & Access Management)",
-	"aws_imagebuilder_image_recipe":                                    "EC2 Image Builder",
-	"aws_macie2_custom_data_identifier":                                "Macie",
-	"aws_network_interface_sg_attachment":                              "VPC (Virtual Private Cloud)",
-	"aws_opensearchserverless_vpc_endpoint":                            "OpenSearch Serverless",
-	"aws_dms_replication_instance":                                     "DMS (Database Migration)",
-	"aws_redshift_snapshot_copy_grant":                                 "Redshift",
-	"aws_sagemaker_flow_definition":                                    "SageMaker",
-	"aws_securityhub_member":                                           "Security Hub",
-	"aws_wafregional_web_acl_association":                              "WAF Classic Regional",
-	"aws_organizations_policy":                                         "Organizations",
-	"aws_route53_resolver_config":                                      "Route 53 Resolver",
-	"aws_cloudhsm_v2_hsm":                                              "CloudHSM",
-	"aws_datasync_location_fsx_lustre_file_system":                     "DataSync",
-	"aws_devopsguru_notification_channel":                              "DevOps Guru",
-	"aws_lightsail_instance":                                           "Lightsail",
-	"aws_mwaa_environment":                                             "MWAA (Managed Workflows for Apache Airflow)",
-	"aws_opsworks_memcached_layer":                                     "OpsWorks",
-	"aws_cloud9_environment_ec2":                                       "Cloud9",
-	"aws_msk_serverless_cluster":                                       "Managed Streaming for Kafka",
-	"aws_appsync_type":                                                 "AppSync",
-	"aws_drs_replication_configuration_template":                       "DRS (Elastic Disaster Recovery)",
-	"aws_resourceexplorer2_index":                                      "Resource Explorer",
-	"aws_datasync_agent":                                               "DataSync",
-	"aws_appstream_stack":                                              "AppStream 2.0",
-	"aws_budgets_budget_action":                                        "Web Services Budgets",
-	"aws_cleanrooms_collaboration":                                     "Clean Rooms",
-	"aws_elasticache_user":                                             "ElastiCache",
-	"aws_fsx_openzfs_file_system":                                      "FSx",
-	"aws_fsx_openzfs_snapshot":                                         "FSx",
-	"aws_kinesisanalyticsv2_application":                               "Kinesis Analytics V2",
-	"aws_api_gateway_gateway_response":                                 "API Gateway",
-	"aws_organizations_policy_attachment":                              "Organizations",
-	"aws_vpn_gateway_attachment":                                       "VPN (Site-to-Site)",
-	"aws_neptune_cluster":                                              "Neptune",
-	"aws_lightsail_domain_entry":                                       "Lightsail",
-	"aws_ses_event_destination":                                        "SES (Simple Email)",
-	"aws_transfer_certificate":                                         "Transfer Family",
-	"aws_auditmanager_framework":                                       "Audit Manager",
-	"aws_elasticache_cluster":                                          "ElastiCache",
-	"aws_iam_policy":                                                   "IAM (Identity & Access Management)",
-	"aws_dx_private_virtual_interface":                                 "Direct Connect",
-	"aws_fms_resource_set":                                             "FMS (Firewall Manager)",
-	"aws_iam_role_policy":                                              "IAM (Identity & Access Management)",
-	"aws_iot_provisioning_template":                                    "IoT Core",
-	"aws_route53recoveryreadiness_recovery_group":                      "Route 53 Recovery Readiness",
-	"aws_route53_resolver_firewall_rule_group":                         "Route 53 Resolver",
-	"aws_appmesh_virtual_router":                                       "App Mesh",
-	"aws_connect_user_hierarchy_structure":                             "Connect",
-	"aws_dax_cluster":                                                  "DynamoDB Accelerator (DAX)",
-	"aws_globalaccelerator_listener":                                   "Global Accelerator",
-	"aws_sagemaker_model_package_group":                                "SageMaker",
-	"aws_ssmcontacts_contact_channel":                                  "SSM Contacts",
-	"aws_cloudwatch_log_stream":                                        "CloudWatch Logs",
-	"aws_iam_service_specific_credential":                              "IAM (Identity & Access Management)",
-	"aws_rds_global_cluster":                                           "RDS (Relational Database)",
-	"aws_route53recoverycontrolconfig_control_panel":                   "Route 53 Recovery Control Config",
-	"aws_sns_topic_policy":                                             "SNS (Simple Notification)",
-	"aws_config_organization_conformance_pack":                         "Config",
-	"aws_iam_account_alias":                                            "IAM (Identity & Access Management)",
-	"aws_iot_policy":                                                   "IoT Core",
-	"aws_iot_thing":                                                    "IoT Core",
-	"aws_opsworks_permission":                                          "OpsWorks",
-	"aws_s3_bucket_request_payment_configuration":                      "S3 (Simple Storage)",
-	"aws_signer_signing_job":                                           "Signer",
-	"aws_bedrock_model_invocation_logging_configuration":               "Bedrock",
-	"aws_waf_rule":                                                     "WAF Classic",
-	"aws_vpclattice_resource_policy":                                   "VPC Lattice",
-	"aws_quicksight_group_membership":                                  "QuickSight",
-	"aws_route_table_association":                                      "VPC (Virtual Private Cloud)",
-	"aws_shield_protection":                                            "Shield",
-	"aws_lb_cookie_stickiness_policy":                                  "ELB Classic",
-	"aws_pinpoint_email_channel":                                       "Pinpoint",
-	"aws_s3_bucket_website_configuration":                              "S3 (Simple Storage)",
-	"aws_sqs_queue_redrive_allow_policy":                               "SQS (Simple Queue)",
-	"aws_wafregional_web_acl":                                          "WAF Classic Regional",
-	"aws_ami_from_instance":                                            "EC2 (Elastic Compute Cloud)",
-	"aws_cognito_identity_pool_provider_principal_tag":                 "Cognito Identity",
-	"aws_db_parameter_group":                                           "RDS (Relational Database)",
-	"aws_lb_trust_store_revocation":                                    "ELB (Elastic Load Balancing)",
-	"aws_ses_identity_notification_topic":                              "SES (Simple Email)",
-	"aws_backup_report_plan":                                           "Backup",
-	"aws_dx_macsec_key_association":                                    "Direct Connect",
-	"aws_iot_authorizer":                                               "IoT Core",
-	"aws_licensemanager_license_configuration":                         "License Manager",
-	"aws_route53recoveryreadiness_readiness_check":                     "Route 53 Recovery Readiness",
-	"aws_controltower_landing_zone":                                    "Control Tower",
-	"aws_directory_service_log_subscription":                           "Directory Service",
-	"aws_glue_trigger":                                                 "Glue",
-	"aws_networkfirewall_resource_policy":                              "Network Firewall",
-	"aws_redshift_cluster_iam_roles":                                   "Redshift",
-	"aws_redshiftserverless_endpoint_access":                           "Redshift Serverless",
-	"aws_customer_gateway":                                             "VPN (Site-to-Site)",
-	"aws_ec2_capacity_reservation":                                     "EC2 (Elastic Compute Cloud)",
-	"aws_ecr_repository_policy":                                        "ECR (Elastic Container Registry)",
-	"aws_lambda_layer_version_permission":                              "Lambda",
-	"aws_s3control_access_grants_instance":                             "S3 Control",
-	"aws_ses_receipt_filter":                                           "SES (Simple Email)",
-	"aws_chimesdkvoice_sip_media_application":                          "Chime SDK Voice",
-	"aws_appsync_graphql_api":                                          "AppSync",
-	"aws_cloudwatch_event_api_destination":                             "EventBridge",
-	"aws_network_acl":                                                  "VPC (Virtual Private Cloud)",
-	"aws_ssm_document":                                                 "SSM (Systems Manager)",
-	"aws_api_gateway_method_response":                                  "API Gateway",
-	"aws_autoscalingplans_scaling_plan":                                "Auto Scaling Plans",
-	"aws_cloudwatch_event_rule":                                        "EventBridge",
-	"aws_codebuild_webhook":                                            "CodeBuild",
-	"aws_grafana_workspace_service_account":                            "Managed Grafana",
-	"aws_quicksight_analysis":                                          "QuickSight",
-	"aws_quicksight_namespace":                                         "QuickSight",
-	"aws_redshift_data_share_consumer_association":                     "Redshift",
-	"aws_acmpca_permission":                                            "ACM PCA (Certificate Manager Private Certificate Authority)",
-	"aws_elastic_beanstalk_configuration_template":                     "Elastic Beanstalk",
-	"aws_imagebuilder_distribution_configuration":                      "EC2 Image Builder",
-	"aws_location_map":                                                 "Location",
-	"aws_medialive_multiplex":                                          "Elemental MediaLive",
-	"aws_sagemaker_image_version":                                      "SageMaker",
-	"aws_wafregional_regex_match_set":                                  "WAF Classic Regional",
-	"aws_controltower_control":                                         "Control Tower",
-	"aws_codedeploy_app":                                               "CodeDeploy",
-	"aws_ec2_transit_gateway_route_table_propagation":                  "Transit Gateway",
-	"aws_ram_resource_association":                                     "RAM (Resource Access Manager)",
-	"aws_securityhub_action_target":                                    "Security Hub",
-	"aws_cloudfront_key_value_store":                                   "CloudFront",
-	"aws_docdbelastic_cluster":                                         "DocumentDB Elastic",
-	"aws_ec2_network_insights_analysis":                                "VPC (Virtual Private Cloud)",
-	"aws_networkfirewall_logging_configuration":                        "Network Firewall",
-	"aws_networkmanager_transit_gateway_connect_peer_association":      "Network Manager",
-	"aws_oam_sink_policy":                                              "CloudWatch Observability Access Manager",
-	"aws_route53_resolver_firewall_config":                             "Route 53 Resolver",
-	"aws_sagemaker_device":                                             "SageMaker",
-	"aws_datapipeline_pipeline":                                        "Data Pipeline",
-	"aws_schemas_registry":                                             "EventBridge Schemas",
-	"aws_network_acl_rule":                                             "VPC (Virtual Private Cloud)",
-	"aws_kms_grant":                                                    "KMS (Key Management)",
-	"aws_cloudfront_cache_policy":                                      "CloudFront",
-	"aws_dynamodb_kinesis_streaming_destination":                       "DynamoDB",
-	"aws_servicequotas_service_quota":                                  "Service Quotas",
-	"aws_amplify_backend_environment":                                  "Amplify",
-	"aws_backup_vault_lock_configuration":                              "Backup",
-	"aws_cloudformation_type":                                          "CloudFormation",
-	"aws_connect_vocabulary":                                           "Connect",
-	"aws_ecrpublic_repository":                                         "ECR Public",
-	"aws_networkmanager_global_network":                                "Network Manager",
-	"aws_service_discovery_instance":                                   "Cloud Map",
-	"aws_vpc_endpoint_connection_accepter":                             "VPC (Virtual Private Cloud)",
-	"aws_apigatewayv2_api":                                             "API Gateway V2",
-	"aws_vpc_peering_connection_options":                               "VPC (Virtual Private Cloud)",
-	"aws_lakeformation_permissions":                                    "Lake Formation",
-	"aws_opensearch_domain_policy":                                     "OpenSearch",
-	"aws_dx_gateway_association_proposal":                              "Direct Connect",
-	"aws_lightsail_lb_https_redirection_policy":                        "Lightsail",
-	"aws_route53_resolver_rule_association":                            "Route 53 Resolver",
-	"aws_servicecatalog_tag_option":                                    "Service Catalog",
-	"aws_sqs_queue_policy":                                             "SQS (Simple Queue)",
-	"aws_bedrockagent_agent_knowledge_base_association":                "Bedrock Agents",
-	"aws_kinesis_stream":                                               "Kinesis",
-	"aws_route53_health_check":                                         "Route 53",
-	"aws_sagemaker_user_profile":                                       "SageMaker",
-	"aws_ses_template":                                                 "SES (Simple Email)",
-	"aws_timestreamwrite_database":                                     "Timestream Write",
-	"aws_globalaccelerator_cross_account_attachment":                   "Global Accelerator",
-	"aws_datazone_environment_blueprint_configuration":                 "DataZone",
-	"aws_datapipeline_pipeline_definition":                             "Data Pipeline",
-	"aws_cognito_user_pool_client":                                     "Cognito IDP (Identity Provider)",
-	"aws_servicecatalog_principal_portfolio_association":               "Service Catalog",
-	"aws_apigatewayv2_route":                                           "API Gateway V2",
-	"aws_cloudwatch_composite_alarm":                                   "CloudWatch",
-	"aws_dataexchange_revision":                                        "Data Exchange",
-	"aws_directory_service_directory":                                  "Directory Service",
-	"aws_networkmanager_transit_gateway_registration":                  "Network Manager",
-	"aws_route53_hosted_zone_dnssec":                                   "Route 53",
-	"aws_sagemaker_device_fleet":                                       "SageMaker",
-	"aws_verifiedpermissions_identity_source":                          "Verified Permissions",
-	"aws_api_gateway_stage":                                            "API Gateway",
-	"aws_bedrock_custom_model":                                         "Bedrock",
-	"aws_ec2_client_vpn_endpoint":                                      "VPN (Client)",
-	"aws_apigatewayv2_deployment":                                      "API Gateway V2",
-	"aws_grafana_workspace":                                            "Managed Grafana",
-	"aws_iot_thing_group_membership":                                   "IoT Core",
-	"aws_lightsail_static_ip_attachment":                               "Lightsail",
-	"aws_route53domains_registered_domain":                             "Route 53 Domains",
-	"aws_vpc_endpoint_subnet_association":                              "VPC (Virtual Private Cloud)",
-	"aws_appmesh_virtual_node":                                         "App Mesh",
-	"aws_opensearch_domain":                                            "OpenSearch",
-	"aws_rds_cluster_role_association":                                 "RDS (Relational Database)",
-	"aws_redshiftserverless_custom_domain_association":                 "Redshift Serverless",
-	"aws_ssmincidents_replication_set":                                 "SSM Incident Manager Incidents",
-	"aws_load_balancer_policy":                                         "ELB Classic",
-	"aws_iam_group_policy_attachment":                                  "IAM (Identity & Access Management)",
-	"aws_networkmanager_vpc_attachment":                                "Network Manager",
-	"aws_securityhub_account":                                          "Security Hub",
-	"aws_ecs_task_definition":                                          "ECS (Elastic Container)",
-	"aws_redshift_parameter_group":                                     "Redshift",
-	"aws_networkmanager_link":                                          "Network Manager",
-	"aws_dynamodb_resource_policy":                                     "DynamoDB",
-	"aws_guardduty_invite_accepter":                                    "GuardDuty",
-	"aws_lb_target_group":                                              "ELB (Elastic Load Balancing)",
-	"aws_s3_object_copy":                                               "S3 (Simple Storage)",
-	"aws_ssm_maintenance_window_target":                                "SSM (Systems Manager)",
-	"aws_config_retention_configuration":                               "Config",
-	"aws_neptune_parameter_group":                                      "Neptune",
-	"aws_redshift_logging":                                             "Redshift",
-	"aws_kendra_data_source":                                           "Kendra",
-	"aws_backup_vault":                                                 "Backup",
-	"aws_iam_user_login_profile":                                       "IAM (Identity & Access Management)",
-	"aws_imagebuilder_component":                                       "EC2 Image Builder",
-	"aws_imagebuilder_image_pipeline":                                  "EC2 Image Builder",
-	"aws_sagemaker_model_package_group_policy":                         "SageMaker",
-	"aws_vpclattice_service":                                           "VPC Lattice",
-	"aws_appsync_domain_name_api_association":                          "AppSync",
-	"aws_ec2_tag":                                                      "EC2 (Elastic Compute Cloud)",
-	"aws_nat_gateway":                                                  "VPC (Virtual Private Cloud)",
-	"aws_quicksight_ingestion":                                         "QuickSight",
-	"aws_auditmanager_account_registration":                            "Audit Manager",
-	"aws_networkmanager_transit_gateway_peering":                       "Network Manager",
-	"aws_ec2_transit_gateway_prefix_list_reference":                    "Transit Gateway",
-	"aws_ssoadmin_application":                                         "SSO Admin",
-	"aws_transcribe_medical_vocabulary":                                "Transcribe",
-	"aws_vpclattice_listener":                                          "VPC Lattice",
-	"aws_vpn_gateway":                                                  "VPN (Site-to-Site)",
-	"aws_autoscaling_group_tag":                                        "Auto Scaling",
-	"aws_internet_gateway_attachment":                                  "VPC (Virtual Private Cloud)",
-	"aws_lakeformation_data_cells_filter":                              "Lake Formation",
-	"aws_networkmanager_site":                                          "Network Manager",
-	"aws_route53_record":                                               "Route 53",
-	"aws_ssmincidents_response_plan":                                   "SSM Incident Manager Incidents",
-	"aws_ec2_client_vpn_authorization_rule":                            "VPN (Client)",
-	"aws_redshift_resource_policy":                                     "Redshift",
-	"aws_s3_bucket_ownership_controls":                                 "S3 (Simple Storage)",
-	"aws_vpc_dhcp_options_association":                                 "VPC (Virtual Private Cloud)",
-	"aws_cloudwatch_query_definition":                                  "CloudWatch Logs",
-	"aws_budgets_budget":                                               "Web Services Budgets",
-	"aws_ec2_transit_gateway_vpc_attachment":                           "Transit Gateway",
-	"aws_ecr_registry_scanning_configuration":                          "ECR (Elastic Container Registry)",
-	"aws_ecs_task_set":                                                 "ECS (Elastic Container)",
-	"aws_elasticache_subnet_group":                                     "ElastiCache",
-	"aws_gamelift_alias":                                               "GameLift",
-	"aws_glue_catalog_database":                                        "Glue",
-	"aws_api_gateway_request_validator":                                "API Gateway",
-	"aws_s3control_object_lambda_access_point_policy":                  "S3 Control",
-	"aws_sagemaker_notebook_instance_lifecycle_configuration":          "SageMaker",
-	"aws_opsworks_rds_db_instance":                                     "OpsWorks",
-	"aws_auditmanager_assessment":                                      "Audit Manager",
-	"aws_chime_voice_connector_termination_credentials":                "Chime",
-	"aws_codegurureviewer_repository_association":                      "CodeGuru Reviewer",
-	"aws_lightsail_instance_public_ports":                              "Lightsail",
-	"aws_networkmanager_link_association":                              "Network Manager",
-	"aws_vpclattice_service_network_vpc_association":                   "VPC Lattice",
-	"aws_vpn_connection_route":                                         "VPN (Site-to-Site)",
-	"aws_api_gateway_rest_api_policy":                                  "API Gateway",
-	"aws_cloudformation_stack":                                         "CloudFormation",
-	"aws_datasync_location_nfs":                                        "DataSync",
-	"aws_directory_service_shared_directory":                           "Directory Service",
-	"aws_fsx_ontap_storage_virtual_machine":                            "FSx",
-	"aws_location_tracker":                                             "Location",
-	"aws_s3_bucket":                                                    "S3 (Simple Storage)",
-	"aws_appconfig_application":                                        "AppConfig",
-	"aws_ec2_network_insights_path":                                    "VPC (Virtual Private Cloud)",
-	"aws_guardduty_ipset":                                              "GuardDuty",
-	"aws_quicksight_folder":                                            "QuickSight",
-	"aws_transfer_agreement":                                           "Transfer Family",
-	"aws_vpc_ipam_scope":                                               "VPC IPAM (IP Address Manager)",
-	"aws_waf_regex_pattern_set":                                        "WAF Classic",
-	"aws_wafregional_rate_based_rule":                                  "WAF Classic Regional",
-	"aws_datasync_location_fsx_ontap_file_system":                      "DataSync",
-	"aws_ec2_instance_metadata_defaults":                               "EC2 (Elastic Compute Cloud)",
-	"aws_identitystore_group_membership":                               "SSO Identity Store",
-	"aws_networkfirewall_firewall":                                     "Network Firewall",
-	"aws_pinpoint_app":                                                 "Pinpoint",
-	"aws_sesv2_configuration_set_event_destination":                    "SESv2 (Simple Email V2)",
-	"aws_codestarnotifications_notification_rule":                      "CodeStar Notifications",
-	"aws_sesv2_configuration_set":                                      "SESv2 (Simple Email V2)",
-	"aws_wafv2_ip_set":                                                 "WAF",
-	"aws_lexv2models_bot":                                              "Lex V2 Models",
-	"aws_s3control_storage_lens_configuration":                         "S3 Control",
-	"aws_sagemaker_studio_lifecycle_config":                            "SageMaker",
-	"aws_synthetics_canary":                                            "CloudWatch Synthetics",
-	"aws_route53_vpc_association_authorization":                        "Route 53",
-	"aws_servicecatalog_budget_resource_association":                   "Service Catalog",
-	"aws_ses_receipt_rule":                                             "SES (Simple Email)",
-	"aws_msk_cluster_policy":                                           "Managed Streaming for Kafka",
-	"aws_location_route_calculator":                                    "Location",
-	"aws_memorydb_subnet_group":                                        "MemoryDB for Redis",
-	"aws_opensearchserverless_security_policy":                         "OpenSearch Serverless",
-	"aws_chimesdkvoice_sip_rule":                                       "Chime SDK Voice",
-	"aws_dx_hosted_private_virtual_interface_accepter":                 "Direct Connect",
-	"aws_efs_file_system":                                              "EFS (Elastic File System)",
-	"aws_secretsmanager_secret_policy":                                 "Secrets Manager",
-	"aws_cloudformation_stack_set":                                     "CloudFormation",
-	"aws_comprehend_document_classifier":                               "Comprehend",
-	"aws_quicksight_data_set":                                          "QuickSight",
-	"aws_codecommit_approval_rule_template_association":                "CodeCommit",
-	"aws_rds_cluster_instance":                                         "RDS (Relational Database)",
-	"aws_redshiftserverless_snapshot":                                  "Redshift Serverless",
-	"aws_s3_bucket_cors_configuration":                                 "S3 (Simple Storage)",
-	"aws_vpclattice_auth_policy":                                       "VPC Lattice",
-	"aws_emr_block_public_access_configuration":                        "EMR",
-	"aws_ecr_repository":                                               "ECR (Elastic Container Registry)",
-	"aws_sfn_alias":                                                    "SFN (Step Functions)",
-	"aws_api_gateway_rest_api":                                         "API Gateway",
-	"aws_apigatewayv2_route_response":                                  "API Gateway V2",
-	"aws_securityhub_organization_admin_account":                       "Security Hub",
-	"aws_vpc_dhcp_options":                                             "VPC (Virtual Private Cloud)",
-	"aws_workspaces_workspace":                                         "WorkSpaces",
-	"aws_api_gateway_integration":                                      "API Gateway",
-	"aws_ssm_resource_data_sync":                                       "SSM (Systems Manager)",
-	"aws_pinpoint_apns_voip_channel":                                   "Pinpoint",
-	"aws_networkmanager_connect_attachment":                            "Network Manager",
-	"aws_athena_prepared_statement":                                    "Athena",
-	"aws_ec2_transit_gateway_multicast_group_source":                   "Transit Gateway",
-	"aws_oam_sink":                                                     "CloudWatch Observability Access Manager",
-	"aws_verifiedaccess_instance_trust_provider_attachment":            "Verified Access",
-	"aws_db_event_subscription":                                        "RDS (Relational Database)",
-	"aws_athena_workgroup":                                             "Athena",
-	"aws_backup_vault_notifications":                                   "Backup",
-	"aws_devicefarm_network_profile":                                   "Device Farm",
-	"aws_finspace_kx_database":                                         "FinSpace",
-	"aws_s3control_access_grants_location":                             "S3 Control",
-	"aws_storagegateway_smb_file_share":                                "Storage Gateway",
-	"aws_verifiedaccess_instance":                                      "Verified Access",
-	"aws_appautoscaling_target":                                        "Application Auto Scaling",
-	"aws_devopsguru_event_sources_config":                              "DevOps Guru",
-	"aws_fsx_backup":                                                   "FSx",
-	"aws_lakeformation_data_lake_settings":                             "Lake Formation",
-	"aws_media_convert_queue":                                          "Elemental MediaConvert",
-	"aws_ssoadmin_account_assignment":                                  "SSO Admin",
-	"aws_vpc_peering_connection":                                       "VPC (Virtual Private Cloud)",
-	"aws_apprunner_service":                                            "App Runner",
-	"aws_athena_database":                                              "Athena",
-	"aws_docdb_cluster_instance":                                       "DocumentDB",
-	"aws_ebs_encryption_by_default":                                    "EBS (EC2)",
-	"aws_iot_billing_group":                                            "IoT Core",
-	"aws_networkmanager_core_network":                                  "Network Manager",
-	"aws_s3_bucket_object":                                             "S3 (Simple Storage)",
-	"aws_ssmcontacts_contact":                                          "SSM Contacts",
-	"aws_appfabric_app_bundle":                                         "AppFabric",
-	"aws_lightsail_disk_attachment":                                    "Lightsail",
-	"aws_vpc_ipam_pool":                                                "VPC IPAM (IP Address Manager)",
-	"aws_directory_service_conditional_forwarder":                      "Directory Service",
-	"aws_iam_security_token_service_preferences":                       "IAM (Identity & Access Management)",
-	"aws_lakeformation_lf_tag":                                         "Lake Formation",
-	"aws_msk_replicator":                                               "Managed Streaming for Kafka",
-	"aws_ssm_parameter":                                                "SSM (Systems Manager)",
-	"aws_vpc_ipam_resource_discovery_association":                      "VPC IPAM (IP Address Manager)",
-	"aws_appfabric_app_authorization_connection":                       "AppFabric",
-	"aws_lb_listener_certificate":                                      "ELB (Elastic Load Balancing)",
-	"aws_media_store_container_policy":                                 "Elemental MediaStore",
-	"aws_ecr_replication_configuration":                                "ECR (Elastic Container Registry)",
-	"aws_devopsguru_resource_collection":                               "DevOps Guru",
-	"aws_route53_key_signing_key":                                      "Route 53",
-	"aws_servicecatalog_portfolio_share":                               "Service Catalog",
-	"aws_datasync_location_fsx_windows_file_system":                    "DataSync",
-	"aws_mq_broker":                                                    "MQ",
-	"aws_rekognition_stream_processor":                                 "Rekognition",
-	"aws_elb_attachment":                                               "ELB Classic",
-	"aws_iam_account_password_policy":                                  "IAM (Identity & Access Management)",
-	"aws_oam_link":                                                     "CloudWatch Observability Access Manager",
-	"aws_rds_export_task":                                              "RDS (Relational Database)",
-	"aws_redshiftdata_statement":                                       "Redshift Data",
-	"aws_sagemaker_workteam":                                           "SageMaker",
-	"aws_directory_service_region":                                     "Directory Service",
-	"aws_bedrockagent_knowledge_base":                                  "Bedrock Agents",
-	"aws_cloudfront_origin_access_control":                             "CloudFront",
-	"aws_cloudwatch_event_archive":                                     "EventBridge",
-	"aws_dynamodb_contributor_insights":                                "DynamoDB",
-	"aws_pinpoint_gcm_channel":                                         "Pinpoint",
-	"aws_prometheus_scraper":                                           "AMP (Managed Prometheus)",
-	"aws_transfer_workflow":                                            "Transfer Family",
-	"aws_appflow_connector_profile":                                    "AppFlow",
-	"aws_ec2_image_block_public_access":                                "EC2 (Elastic Compute Cloud)",
-	"aws_eks_access_policy_association":                                "EKS (Elastic Kubernetes)",
-	"aws_lightsail_lb_attachment":                                      "Lightsail",
-	"aws_osis_pipeline":                                                "OpenSearch Ingestion",
-	"aws_cloudwatch_log_account_policy":                                "CloudWatch Logs",
-	"aws_docdb_cluster_snapshot":                                       "DocumentDB",
-	"aws_lightsail_lb_certificate":                                     "Lightsail",
-	"aws_mskconnect_connector":                                         "Managed Streaming for Kafka Connect",
-	"aws_wafregional_byte_match_set":                                   "WAF Classic Regional",
-	"aws_worklink_website_certificate_authority_association":           "WorkLink",
-	"aws_backup_framework":                                             "Backup",
-	"aws_codeartifact_domain_permissions_policy":                       "CodeArtifact",
-	"aws_fsx_file_cache":                                               "FSx",
-	"aws_networkmanager_transit_gateway_route_table_attachment":        "Network Manager",
-	"aws_s3_bucket_public_access_block":                                "S3 (Simple Storage)",
-	"aws_codeartifact_domain":                                          "CodeArtifact",
-	"aws_evidently_launch":                                             "CloudWatch Evidently",
-	"aws_finspace_kx_user":                                             "FinSpace",
-	"aws_glue_ml_transform":                                            "Glue",
-	"aws_kinesis_firehose_delivery_stream":                             "Kinesis Firehose",
-	"aws_macie2_findings_filter":                                       "Macie",
-	"aws_opensearch_package_association":                               "OpenSearch",
-	"aws_opsworks_php_app_layer":                                       "OpsWorks",
-	"aws_cleanrooms_configured_table":                                  "Clean Rooms",
-	"aws_shield_protection_group":                                      "Shield",
-	"aws_evidently_project":                                            "CloudWatch Evidently",
-	"aws_medialive_input":                                              "Elemental MediaLive",
-	"aws_proxy_protocol_policy":                                        "ELB Classic",
-	"aws_quicksight_template":                                          "QuickSight",
-	"aws_codeguruprofiler_profiling_group":                             "CodeGuru Profiler",
-	"aws_db_proxy_endpoint":                                            "RDS (Relational Database)",
-	"aws_gamelift_fleet":                                               "GameLift",
-	"aws_globalaccelerator_custom_routing_listener":                    "Global Accelerator",
-	"aws_lb_listener_rule":                                             "ELB (Elastic Load Balancing)",
-	"aws_lightsail_key_pair":                                           "Lightsail",
-	"aws_opensearch_vpc_endpoint":                                      "OpenSearch",
-	"aws_opsworks_custom_layer":                                        "OpsWorks",
-	"aws_cloudfront_origin_access_identity":                            "CloudFront",
-	"aws_signer_signing_profile_permission":                            "Signer",
-	"aws_grafana_workspace_saml_configuration":                         "Managed Grafana",
-	"aws_kinesis_analytics_application":                                "Kinesis Analytics",
-	"aws_secretsmanager_secret_version":                                "Secrets Manager",
-	"aws_appsync_function":                                             "AppSync",
-	"aws_fsx_data_repository_association":                              "FSx",
-	"aws_iot_topic_rule":                                               "IoT Core",
-	"aws_lambda_function":                                              "Lambda",
-	"aws_transcribe_language_model":                                    "Transcribe",
-	"aws_transcribe_vocabulary_filter":                                 "Transcribe",
-	"aws_cognito_user_pool_ui_customization":                           "Cognito IDP (Identity Provider)",
-	"aws_emrcontainers_virtual_cluster":                                "EMR Containers",
-	"aws_lambda_function_url":                                          "Lambda",
-	"aws_networkmonitor_probe":                                         "CloudWatch Network Monitor",
-	"aws_route53domains_delegation_signer_record":                      "Route 53 Domains",
-	"aws_ebs_snapshot_copy":                                            "EBS (EC2)",
-	"aws_lightsail_domain":                                             "Lightsail",
-	"aws_s3_account_public_access_block":                               "S3 Control",
-	"aws_transfer_user":                                                "Transfer Family",
-	"aws_wafregional_ipset":                                            "WAF Classic Regional",
-	"aws_api_gateway_resource":                                         "API Gateway",
-	"aws_cognito_user_group":                                           "Cognito IDP (Identity Provider)",
-	"aws_default_route_table":                                          "VPC (Virtual Private Cloud)",
-	"aws_dx_hosted_public_virtual_interface":                           "Direct Connect",
-	"aws_ec2_managed_prefix_list_entry":                                "VPC (Virtual Private Cloud)",
-	"aws_macie2_classification_export_configuration":                   "Macie",
-	"aws_vpc_endpoint_service":                                         "VPC (Virtual Private Cloud)",
-	"aws_cloudwatch_log_group":                                         "CloudWatch Logs",
-	"aws_cognito_risk_configuration":                                   "Cognito IDP (Identity Provider)",
-	"aws_datazone_domain":                                              "DataZone",
-	"aws_ebs_snapshot_import":                                          "EBS (EC2)",
-	"aws_ec2_instance_connect_endpoint":                                "EC2 (Elastic Compute Cloud)",
-	"aws_eks_cluster":                                                  "EKS (Elastic Kubernetes)",
-	"aws_elasticache_user_group_association":                           "ElastiCache",
-	"aws_identitystore_group":                                          "SSO Identity Store",
-	"aws_amplify_app":                                                  "Amplify",
-	"aws_quicksight_theme":                                             "QuickSight",
-	"aws_opensearch_domain_saml_options":                               "OpenSearch",
-	"aws_glue_data_quality_ruleset":                                    "Glue",
-	"aws_ec2_transit_gateway_multicast_domain":                         "Transit Gateway",
-	"aws_gamelift_script":                                              "GameLift",
-	"aws_redshiftserverless_usage_limit":                               "Redshift Serverless",
-	"aws_s3control_object_lambda_access_point":                         "S3 Control",
-	"aws_db_cluster_snapshot":                                          "RDS (Relational Database)",
-	"aws_auditmanager_control":                                         "Audit Manager",
-	"aws_cloudsearch_domain":                                           "CloudSearch",
-	"aws_config_configuration_recorder":                                "Config",
-	"aws_sagemaker_servicecatalog_portfolio_status":                    "SageMaker",
-	"aws_wafregional_xss_match_set":                                    "WAF Classic Regional",
-	"aws_acmpca_certificate_authority":                                 "ACM PCA (Certificate Manager Private Certificate Authority)",
-	"aws_api_gateway_usage_plan":                                       "API Gateway",
-	"aws_chimesdkvoice_global_settings":                                "Chime SDK Voice",
-	"aws_cloudfront_realtime_log_config":                               "CloudFront",
-	"aws_docdb_event_subscription":                                     "DocumentDB",
-	"aws_iot_certificate":                                              "IoT Core",
-	"aws_networkfirewall_firewall_policy":                              "Network Firewall",
-	"aws_securityhub_standards_control":                                "Security Hub",
-	"aws_api_gateway_method":                                           "API Gateway",
-	"aws_docdb_cluster_parameter_group":                                "DocumentDB",
-	"aws_quicksight_dashboard":                                         "QuickSight",
-	"aws_synthetics_group_association":                                 "CloudWatch Synthetics",
-	"aws_detective_member":                                             "Detective",
-	"aws_config_configuration_aggregator":                              "Config",
-	"aws_dx_connection_confirmation":                                   "Direct Connect",
-	"aws_ec2_transit_gateway_policy_table_association":                 "Transit Gateway",
-	"aws_ecs_cluster_capacity_providers":                               "ECS (Elastic Container)",
-	"aws_inspector2_organization_configuration":                        "Inspector",
-	"aws_ivs_channel":                                                  "IVS (Interactive Video)",
-	"aws_neptune_cluster_snapshot":                                     "Neptune",
-	"aws_codecatalyst_dev_environment":                                 "CodeCatalyst",
-	"aws_rds_cluster_activity_stream":                                  "RDS (Relational Database)",
-	"aws_route53_zone":                                                 "Route 53",
-	"aws_s3_bucket_analytics_configuration":                            "S3 (Simple Storage)",
-	"aws_quicksight_vpc_connection":                                    "QuickSight",
-	"aws_api_gateway_authorizer":                                       "API Gateway",
-	"aws_appconfig_deployment":                                         "AppConfig",
-	"aws_inspector2_delegated_admin_account":                           "Inspector",
-	"aws_lambda_layer_version":                                         "Lambda",
-	"aws_vpc_endpoint_security_group_association":                      "VPC (Virtual Private Cloud)",
-	"aws_ami_copy":                                                     "EC2 (Elastic Compute Cloud)",
-	"aws_batch_scheduling_policy":                                      "Batch",
-	"aws_codeartifact_repository":                                      "CodeArtifact",
-	"aws_emr_cluster":                                                  "EMR",
-	"aws_grafana_workspace_api_key":                                    "Managed Grafana",
-	"aws_redshiftserverless_resource_policy":                           "Redshift Serverless",
-	"aws_vpclattice_target_group_attachment":                           "VPC Lattice",
-	"aws_vpc_security_group_ingress_rule":                              "VPC (Virtual Private Cloud)",
-	"aws_apprunner_auto_scaling_configuration_version":                 "App Runner",
-	"aws_appmesh_route":                                                "App Mesh",
-	"aws_datazone_project":                                             "DataZone",
-	"aws_emr_studio_session_mapping":                                   "EMR",
-	"aws_networkmanager_device":                                        "Network Manager",
-	"aws_securitylake_aws_log_source":                                  "Security Lake",
-	"aws_transfer_ssh_key":                                             "Transfer Family",
-	"aws_xray_encryption_config":                                       "X-Ray",
-	"aws_appconfig_deployment_strategy":                                "AppConfig",
-	"aws_guardduty_organization_admin_account":                         "GuardDuty",
-	"aws_imagebuilder_container_recipe":                                "EC2 Image Builder",
-	"aws_licensemanager_association":                                   "License Manager",
-	"aws_cloudfront_distribution":                                      "CloudFront",
-	"aws_ec2_capacity_block_reservation":                               "EC2 (Elastic Compute Cloud)",
-	"aws_msk_cluster":                                                  "Managed Streaming for Kafka",
-	"aws_opensearchserverless_lifecycle_policy":                        "OpenSearch Serverless",
-	"aws_connect_phone_number":                                         "Connect",
-	"aws_ec2_local_gateway_route_table_vpc_association":                "Outposts (EC2)",
-	"aws_codepipeline_custom_action_type":                              "CodePipeline",
-	"aws_cloudtrail_event_data_store":                                  "CloudTrail",
-	"aws_connect_instance":                                             "Connect",
-	"aws_connect_quick_connect":                                        "Connect",
-	"aws_eks_node_group":                                               "EKS (Elastic Kubernetes)",
-	"aws_kms_external_key":                                             "KMS (Key Management)",
-	"aws_network_interface":                                            "VPC (Virtual Private Cloud)",
-	"aws_route53_resolver_firewall_domain_list":                        "Route 53 Resolver",
-	"aws_bedrockagent_agent_action_group":                              "Bedrock Agents",
-	"aws_wafregional_sql_injection_match_set":                          "WAF Classic Regional",
-	"aws_cloudfront_function":                                          "CloudFront",
-	"aws_cloudtrail":                                                   "CloudTrail",
-	"aws_ec2_fleet":                                                    "EC2 (Elastic Compute Cloud)",
-	"aws_guardduty_organization_configuration_feature":                 "GuardDuty",
-	"aws_keyspaces_table":                                              "Keyspaces (for Apache Cassandra)",
-	"aws_pinpoint_apns_voip_sandbox_channel":                           "Pinpoint",
-	"aws_prometheus_rule_group_namespace":                              "AMP (Managed Prometheus)",
-	"aws_appconfig_environment":                                        "AppConfig",
-	"aws_dx_hosted_transit_virtual_interface_accepter":                 "Direct Connect",
-	"aws_elasticsearch_domain":                                         "Elasticsearch",
-	"aws_networkfirewall_tls_inspection_configuration":                 "Network Firewall",
-	"aws_opsworks_nodejs_app_layer":                                    "OpsWorks",
-	"aws_redshift_scheduled_action":                                    "Redshift",
-	"aws_sagemaker_human_task_ui":                                      "SageMaker",
-	"aws_schemas_discoverer":                                           "EventBridge Schemas",
-	"aws_app_cookie_stickiness_policy":                                 "ELB Classic",
-	"aws_ses_identity_policy":                                          "SES (Simple Email)",
-	"aws_ssmcontacts_rotation":                                         "SSM Contacts",
-	"aws_service_discovery_private_dns_namespace":                      "Cloud Map",
-	"aws_cloudfront_response_headers_policy":                           "CloudFront",
-	"aws_ec2_traffic_mirror_session":                                   "VPC (Virtual Private Cloud)",
-	"aws_key_pair":                                                     "EC2 (Elastic Compute Cloud)",
-	"aws_redshift_cluster":                                             "Redshift",
-	"aws_appfabric_app_authorization":                                  "AppFabric",
-	"aws_lb_target_group_attachment":                                   "ELB (Elastic Load Balancing)",
-	"aws_opsworks_ecs_cluster_layer":                                   "OpsWorks",
-	"aws_secretsmanager_secret":                                        "Secrets Manager",
-	"aws_snapshot_create_volume_permission":                            "EBS (EC2)",
-	"aws_ecr_registry_policy":                                          "ECR (Elastic Container Registry)",
-	"aws_devicefarm_test_grid_project":                                 "Device Farm",
-	"aws_gamelift_build":                                               "GameLift",
-	"aws_shield_drt_access_role_arn_association":                       "Shield",
-	"aws_sqs_queue_redrive_policy":                                     "SQS (Simple Queue)",
-	"aws_appsync_datasource":                                           "AppSync",
-	"aws_connect_user_hierarchy_group":                                 "Connect",
-	"aws_detective_organization_configuration":                         "Detective",
-	"aws_opsworks_instance":                                            "OpsWorks",
-	"aws_ram_principal_association":                                    "RAM (Resource Access Manager)",
-	"aws_securitylake_subscriber_notification":                         "Security Lake",
-	"aws_api_gateway_usage_plan_key":                                   "API Gateway",
-	"aws_launch_template":                                              "EC2 (Elastic Compute Cloud)",
-	"aws_opsworks_stack":                                               "OpsWorks",
-	"aws_spot_instance_request":                                        "EC2 (Elastic Compute Cloud)",
-	"aws_inspector_assessment_template":                                "Inspector Classic",
-	"aws_api_gateway_client_certificate":                               "API Gateway",
-	"aws_apigatewayv2_domain_name":                                     "API Gateway V2",
-	"aws_connect_contact_flow_module":                                  "Connect",
-	"aws_ec2_serial_console_access":                                    "EC2 (Elastic Compute Cloud)",
-	"aws_finspace_kx_scaling_group":                                    "FinSpace",
-	"aws_guardduty_publishing_destination":                             "GuardDuty",
-	"aws_kms_alias":                                                    "KMS (Key Management)",
-	"aws_acm_certificate_validation":                                   "ACM (Certificate Manager)",
-	"aws_vpc_ipam_resource_discovery":                                  "VPC IPAM (IP Address Manager)",
-	"aws_lightsail_certificate":                                        "Lightsail",
-	"aws_chime_voice_connector_streaming":                              "Chime",
-	"aws_codestarconnections_connection":                               "CodeStar Connections",
-	"aws_iam_user":                                                     "IAM (Identity & Access Management)",
-	"aws_lightsail_container_service_deployment_version":               "Lightsail",
-	"aws_msk_configuration":                                            "Managed Streaming for Kafka",
-	"aws_s3control_multi_region_access_point":                          "S3 Control",
-	"aws_servicecatalog_portfolio":                                     "Service Catalog",
-	"aws_appfabric_ingestion":                                          "AppFabric",
-	"aws_ses_receipt_rule_set":                                         "SES (Simple Email)",
-	"aws_dx_public_virtual_interface":                                  "Direct Connect",
-	"aws_elastic_beanstalk_application":                                "Elastic Beanstalk",
-	"aws_route53_zone_association":                                     "Route 53",
-	"aws_sesv2_email_identity_policy":                                  "SESv2 (Simple Email V2)",
-	"aws_datasync_location_smb":                                        "DataSync",
-	"aws_dms_event_subscription":                                       "DMS (Database Migration)",
-	"aws_dx_transit_virtual_interface":                                 "Direct Connect",
-	"aws_glue_data_catalog_encryption_settings":                        "Glue",
-	"aws_iam_saml_provider":                                            "IAM (Identity & Access Management)",
-	"aws_codestarconnections_host":                                     "CodeStar Connections",
-	"aws_default_subnet":                                               "VPC (Virtual Private Cloud)",
-	"aws_eip":                                                          "EC2 (Elastic Compute Cloud)",
-	"aws_medialive_multiplex_program":                                  "Elemental MediaLive",
-	"aws_qldb_ledger":                                                  "QLDB (Quantum Ledger Database)",
-	"aws_transfer_tag":                                                 "Transfer Family",
-	"aws_auditmanager_assessment_delegation":                           "Audit Manager",
-	"aws_fsx_ontap_file_system":                                        "FSx",
-	"aws_glue_user_defined_function":                                   "Glue",
-	"aws_s3outposts_endpoint":                                          "S3 on Outposts",
-	"aws_sqs_queue":                                                    "SQS (Simple Queue)",
-	"aws_vpc_network_performance_metric_subscription":                  "VPC (Virtual Private Cloud)",
-	"aws_cognito_resource_server":                                      "Cognito IDP (Identity Provider)",
-	"aws_cognito_user":                                                 "Cognito IDP (Identity Provider)",
-	"aws_dynamodb_table":                                               "DynamoDB",
-	"aws_service_discovery_public_dns_namespace":                       "Cloud Map",
-	"aws_ssm_association":                                              "SSM (Systems Manager)",
-	"aws_waf_web_acl":                                                  "WAF Classic",
-	"aws_cloudwatch_event_bus_policy":                                  "EventBridge",
-	"aws_guardduty_detector":                                           "GuardDuty",
-	"aws_macie2_member":                                                "Macie",
-	"aws_opsworks_mysql_layer":                                         "OpsWorks",
-	"aws_amplify_branch":                                               "Amplify",
-	"aws_sagemaker_code_repository":                                    "SageMaker",
-	"aws_ssmcontacts_plan":                                             "SSM Contacts",
-	"aws_acmpca_certificate_authority_certificate":                     "ACM PCA (Certificate Manager Private Certificate Authority)",
-	"aws_globalaccelerator_custom_routing_accelerator":                 "Global Accelerator",
-	"aws_rum_app_monitor":                                              "CloudWatch RUM",
-	"aws_s3_directory_bucket":                                          "S3 (Simple Storage)",
-	"aws_vpc_ipam_pool_cidr_allocation":                                "VPC IPAM (IP Address Manager)",
-	"aws_appautoscaling_scheduled_action":                              "Application Auto Scaling",
-	"aws_default_vpc_dhcp_options":                                     "VPC (Virtual Private Cloud)",
-	"aws_iot_thing_principal_attachment":                               "IoT Core",
-	"aws_networkmanager_customer_gateway_association":                  "Network Manager",
-	"aws_placement_group":                                              "EC2 (Elastic Compute Cloud)",
-	"aws_backup_region_settings":                                       "Backup",
-	"aws_ec2_client_vpn_route":                                         "VPN (Client)",
-	"aws_ssm_patch_baseline":                                           "SSM (Systems Manager)",
-	"aws_dx_connection":                                                "Direct Connect",
-	"aws_connect_queue":                                                "Connect",
-	"aws_eks_access_entry":                                             "EKS (Elastic Kubernetes)",
-	"aws_iam_group_membership":                                         "IAM (Identity & Access Management)",
-	"aws_ssm_activation":                                               "SSM (Systems Manager)",
-	"aws_ssm_patch_group":                                              "SSM (Systems Manager)",
-	"aws_connect_lambda_function_association":                          "Connect",
-	"aws_appstream_user":                                               "AppStream 2.0",
-	"aws_dx_connection_association":                                    "Direct Connect",
-	"aws_ec2_transit_gateway_multicast_domain_association":             "Transit Gateway",
-	"aws_kms_ciphertext":                                               "KMS (Key Management)",
-	"aws_redshift_data_share_authorization":                            "Redshift",
-	"aws_redshift_snapshot_schedule_association":                       "Redshift",
-	"aws_s3_bucket_inventory":                                          "S3 (Simple Storage)",
-	"aws_acm_certificate":                                              "ACM (Certificate Manager)",
-	"aws_sns_topic":                                                    "SNS (Simple Notification)",
-	"aws_volume_attachment":                                            "EBS (EC2)",
-	"aws_sagemaker_workforce":                                          "SageMaker",
-	"aws_ecs_capacity_provider":                                        "ECS (Elastic Container)",
-	"aws_glue_workflow":                                                "Glue",
-	"aws_inspector_assessment_target":                                  "Inspector Classic",
-	"aws_sagemaker_domain":                                             "SageMaker",
-	"aws_signer_signing_profile":                                       "Signer",
-	"aws_dms_replication_subnet_group":                                 "DMS (Database Migration)",
-	"aws_dx_hosted_private_virtual_interface":                          "Direct Connect",
-	"aws_iam_user_ssh_key":                                             "IAM (Identity & Access Management)",
-	"aws_neptune_cluster_parameter_group":                              "Neptune",
-	"aws_servicecatalogappregistry_application":                        "Service Catalog AppRegistry",
-	"aws_servicequotas_template":                                       "Service Quotas",
-	"aws_chime_voice_connector_origination":                            "Chime",
-	"aws_db_instance":                                                  "RDS (Relational Database)",
-	"aws_elastictranscoder_pipeline":                                   "Elastic Transcoder",
-	"aws_ivschat_logging_configuration":                                "IVS (Interactive Video) Chat",
-	"aws_networkfirewall_rule_group":                                   "Network Firewall",
-	"aws_ses_domain_mail_from":                                         "SES (Simple Email)",
-	"aws_athena_data_catalog":                                          "Athena",
-	"aws_iam_instance_profile":                                         "IAM (Identity & Access Management)",
-	"aws_networkmanager_site_to_site_vpn_attachment":                   "Network Manager",
-	"aws_pinpoint_apns_channel":                                        "Pinpoint",
-	"aws_route53_resolver_query_log_config_association":                "Route 53 Resolver",
-	"aws_storagegateway_file_system_association":                       "Storage Gateway",
-	"aws_verifiedpermissions_schema":                                   "Verified Permissions",
-	"aws_ec2_transit_gateway_route":                                    "Transit Gateway",
-	"aws_cognito_identity_pool_roles_attachment":                       "Cognito Identity",
-	"aws_docdb_global_cluster":                                         "DocumentDB",
-	"aws_lightsail_lb_certificate_attachment":                          "Lightsail",
-	"aws_main_route_table_association":                                 "VPC (Virtual Private Cloud)",
-	"aws_quicksight_refresh_schedule":                                  "QuickSight",
-	"aws_securityhub_configuration_policy":                             "Security Hub",
-	"aws_codeartifact_repository_permissions_policy":                   "CodeArtifact",
-	"aws_auditmanager_assessment_report":                               "Audit Manager",
-	"aws_datasync_location_efs":                                        "DataSync",
-	"aws_ec2_instance_state":                                           "EC2 (Elastic Compute Cloud)",
-	"aws_glue_resource_policy":                                         "Glue",
-	"aws_resourcegroups_group":                                         "Resource Groups",
-	"aws_vpc_endpoint_connection_notification":                         "VPC (Virtual Private Cloud)",
-	"aws_appflow_flow":                                                 "AppFlow",
-	"aws_apigatewayv2_authorizer":                                      "API Gateway V2",
-	"aws_ec2_transit_gateway_multicast_group_member":                   "Transit Gateway",
-	"aws_transfer_connector":                                           "Transfer Family",
-	"aws_vpc_ipv4_cidr_block_association":                              "VPC (Virtual Private Cloud)",
-	"aws_waf_sql_injection_match_set":                                  "WAF Classic",
-	"aws_acmpca_policy":                                                "ACM PCA (Certificate Manager Private Certificate Authority)",
-	"aws_servicequotas_template_association":                           "Service Quotas",
-	"aws_networkmanager_core_network_policy_attachment":                "Network Manager",
-	"aws_config_delivery_channel":                                      "Config",
-	"aws_dax_parameter_group":                                          "DynamoDB Accelerator (DAX)",
-	"aws_elasticache_replication_group":                                "ElastiCache",
-	"aws_simpledb_domain":                                              "SDB (SimpleDB)",
-	"aws_vpclattice_service_network_service_association":               "VPC Lattice",
-	"aws_wafv2_regex_pattern_set":                                      "WAF",
-	"aws_appfabric_ingestion_destination":                              "AppFabric",
-	"aws_sagemaker_project":                                            "SageMaker",
-
-	"aws_securityhub_organization_configuration":               "Security Hub",
-	"aws_wafregional_regex_pattern_set":                        "WAF Classic Regional",
-	"aws_amplify_domain_association":                           "Amplify",
-	"aws_codebuild_resource_policy":                            "CodeBuild",
-	"aws_licensemanager_grant":                                 "License Manager",
-	"aws_shield_application_layer_automatic_response":          "Shield",
-	"aws_synthetics_group":                                     "CloudWatch Synthetics",
-	"aws_autoscaling_schedule":                                 "Auto Scaling",
-	"aws_athena_named_query":                                   "Athena",
-	"aws_macie2_account":                                       "Macie",
-	"aws_s3_object":                                            "S3 (Simple Storage)",
-	"aws_appconfig_extension_association":                      "AppConfig",
-	"aws_dx_hosted_connection":                                 "Direct Connect",
-	"aws_kendra_experience":                                    "Kendra",
-	"aws_default_security_group":                               "VPC (Virtual Private Cloud)",
-	"aws_devopsguru_service_integration":                       "DevOps Guru",
-	"aws_gamelift_game_server_group":                           "GameLift",
-	"aws_lakeformation_resource":                               "Lake Formation",
-	"aws_quicksight_user":                                      "QuickSight",
-	"aws_transfer_server":                                      "Transfer Family",
-	"aws_appstream_directory_config":                           "AppStream 2.0",
-	"aws_chime_voice_connector_logging":                        "Chime",
-	"aws_config_organization_managed_rule":                     "Config",
-	"aws_macie2_invitation_accepter":                           "Macie",
-	"aws_sagemaker_endpoint":                                   "SageMaker",
-	"aws_servicecatalog_constraint":                            "Service Catalog",
-	"aws_sesv2_contact_list":                                   "SESv2 (Simple Email V2)",
-	"aws_apprunner_default_auto_scaling_configuration_version": "App Runner",
-	"aws_apprunner_deployment":                                 "App Runner",
-	"aws_ec2_local_gateway_route":                              "Outposts (EC2)",
-	"aws_iot_thing_type":                                       "IoT Core",
-	"aws_lex_bot":                                              "Lex Model Building",
-	"aws_pinpoint_sms_channel":                                 "Pinpoint",
-	"aws_rds_cluster_parameter_group":                          "RDS (Relational Database)",
-	"aws_rds_custom_db_engine_version":                         "RDS (Relational Database)",
-	"aws_apprunner_connection":                                 "App Runner",
-	"aws_storagegateway_stored_iscsi_volume":                   "Storage Gateway",
-	"aws_waf_rate_based_rule":                                  "WAF Classic",
-	"aws_route53recoverycontrolconfig_cluster":                 "Route 53 Recovery Control Config",
-	"aws_cloudwatch_log_destination_policy":                    "CloudWatch Logs",
-	"aws_efs_replication_configuration":                        "EFS (Elastic File System)",
-	"aws_networkmonitor_monitor":                               "CloudWatch Network Monitor",
-	"aws_ram_resource_share":                                   "RAM (Resource Access Manager)",
-	"aws_redshift_snapshot_schedule":                           "Redshift",
-	"aws_schemas_schema":                                       "EventBridge Schemas",
-	"aws_servicecatalog_service_action":                        "Service Catalog",
-	"aws_appsync_api_key":                                      "AppSync",
-	"aws_spot_datafeed_subscription":                           "EC2 (Elastic Compute Cloud)",
-	"aws_ec2_transit_gateway_policy_table":                     "Transit Gateway",
-	"aws_imagebuilder_workflow":                                "EC2 Image Builder",
-	"aws_ivschat_room":                                         "IVS (Interactive Video) Chat",
-	"aws_rum_metrics_destination":                              "CloudWatch RUM",
-	"aws_s3_bucket_lifecycle_configuration":                    "S3 (Simple Storage)",
-	"aws_shield_protection_health_check_association":           "Shield",
-	"aws_transfer_access":                                      "Transfer Family",
-	"aws_autoscaling_traffic_source_attachment":                "Auto Scaling",
-	"aws_batch_job_queue":                                      "Batch",
-	"aws_db_instance_role_association":                         "RDS (Relational Database)",
-	"aws_medialive_input_security_group":                       "Elemental MediaLive",
-	"aws_redshift_endpoint_access":                             "Redshift",
-	"aws_security_group":                                       "VPC (Virtual Private Cloud)",
-	"aws_autoscaling_notification":                             "Auto Scaling",
-	"aws_lambda_invocation":                                    "Lambda",
-	"aws_dx_hosted_public_virtual_interface_accepter":          "Direct Connect",
-	"aws_ec2_transit_gateway_route_table_association":          "Transit Gateway",
-	"aws_elasticsearch_domain_policy":                          "Elasticsearch",
-	"aws_iam_user_policy_attachment":                           "IAM (Identity & Access Management)",
-	"aws_m2_environment":                                       "Mainframe Modernization",
-	"aws_ram_resource_share_accepter":                          "RAM (Resource Access Manager)",
-	"aws_db_proxy_default_target_group":                        "RDS (Relational Database)",
-	"aws_lex_bot_alias":                                        "Lex Model Building",
-	"aws_mskconnect_custom_plugin":                             "Managed Streaming for Kafka Connect",
-	"aws_quicksight_iam_policy_assignment":                     "QuickSight",
-	"aws_cloudwatch_log_destination":                           "CloudWatch Logs",
-	"aws_s3_bucket_object_lock_configuration":                  "S3 (Simple Storage)",
-	"aws_datasync_location_object_storage":                     "DataSync",
-	"aws_cloudhsm_v2_cluster":                                  "CloudHSM",
-	"aws_config_organization_custom_policy_rule":               "Config",
-	"aws_datasync_location_azure_blob":                         "DataSync",
-	"aws_ec2_traffic_mirror_filter":                            "VPC (Virtual Private Cloud)",
-	"aws_ec2_traffic_mirror_filter_rule":                       "VPC (Virtual Private Cloud)",
-	"aws_ecr_pull_through_cache_rule":                          "ECR (Elastic Container Registry)",
-	"aws_elasticache_global_replication_group":                 "ElastiCache",
-	"aws_chimesdkvoice_voice_profile_domain":                   "Chime SDK Voice",
-	"aws_guardduty_threatintelset":                             "GuardDuty",
-	"aws_iot_ca_certificate":                                   "IoT Core",
-	"aws_lb_ssl_negotiation_policy":                            "ELB Classic",
-	"aws_memorydb_snapshot":                                    "MemoryDB for Redis",
-	"aws_redshiftserverless_workgroup":                         "Redshift Serverless",
-	"aws_glue_security_configuration":                          "Glue",
-	"aws_appmesh_virtual_service":                              "App Mesh",
-	"aws_dx_lag":                                               "Direct Connect",
-	"aws_lexv2models_slot":                                     "Lex V2 Models",
-	"aws_rds_reserved_instance":                                "RDS (Relational Database)",
-	"aws_appmesh_mesh":                                         "App Mesh",
-	"aws_dynamodb_table_item":                                  "DynamoDB",
-	"aws_ecs_cluster":                                          "ECS (Elastic Container)",
-	"aws_lexv2models_intent":                                   "Lex V2 Models",
-	"aws_lightsail_lb":                                         "Lightsail",
-	"aws_route":                                                "VPC (Virtual Private Cloud)",
-	"aws_ses_domain_identity_verification":                     "SES (Simple Email)",
-	"aws_cloudfront_key_group":                                 "CloudFront",
-	"aws_keyspaces_keyspace":                                   "Keyspaces (for Apache Cassandra)",
-	"aws_lightsail_distribution":                               "Lightsail",
-	"aws_dynamodb_tag":                                         "DynamoDB",
-	"aws_identitystore_user":                                   "SSO Identity Store",
-	"aws_m2_deployment":                                        "Mainframe Modernization",
-	"aws_route53_resolver_firewall_rule":                       "Route 53 Resolver",
-	"aws_s3control_multi_region_access_point_policy":           "S3 Control",
-	"aws_cloudfront_continuous_deployment_policy":              "CloudFront",
-	"aws_emr_security_configuration":                           "EMR",
-	"aws_inspector2_enabler":                                   "Inspector",
-	"aws_iot_domain_configuration":                             "IoT Core",
-	"aws_db_option_group":                                      "RDS (Relational Database)",
-	"aws_cloudwatch_event_permission":                          "EventBridge",
-	"aws_codebuild_source_credential":                          "CodeBuild",
-	"aws_inspector2_member_association":                        "Inspector",
-	"aws_api_gateway_model":                                    "API Gateway",
-	"aws_ce_anomaly_monitor":                                   "CE (Cost Explorer)",
-	"aws_cloudwatch_event_connection":                          "EventBridge",
-	"aws_codecatalyst_source_repository":                       "CodeCatalyst",
-	"aws_appmesh_virtual_gateway":                              "App Mesh",
-	"aws_ec2_host":                                             "EC2 (Elastic Compute Cloud)",
-	"aws_glue_job":                                             "Glue",
-	"aws_kms_replica_external_key":                             "KMS (Key Management)",
-	"aws_opensearchserverless_security_config":                 "OpenSearch Serverless",
-	"aws_redshift_event_subscription":                          "Redshift",
-	"aws_route53_resolver_query_log_config":                    "Route 53 Resolver",
-	"aws_cloudfrontkeyvaluestore_key":                          "CloudFront KeyValueStore",
-	"aws_apigatewayv2_model":                                   "API Gateway V2",
-	"aws_cloudfront_monitoring_subscription":                   "CloudFront",
-	"aws_cloudwatch_log_metric_filter":                         "CloudWatch Logs",
-	"aws_codecommit_repository":                                "CodeCommit",
-	"aws_elastictranscoder_preset":                             "Elastic Transcoder",
-	"aws_verifiedpermissions_policy_template":                  "Verified Permissions",
-	"aws_accessanalyzer_archive_rule":                          "IAM Access Analyzer",
-	"aws_ssoadmin_instance_access_control_attributes":          "SSO Admin",
-	"aws_waf_byte_match_set":                                   "WAF Classic",
-	"aws_cloudwatch_event_target":                              "EventBridge",
-	"aws_grafana_workspace_service_account_token":              "Managed Grafana",
-	"aws_iam_group_policy":                                     "IAM (Identity & Access Management)",
-	"aws_iam_virtual_mfa_device":                               "IAM (Identity & Access Management)",
-	"aws_network_acl_association":                              "VPC (Virtual Private Cloud)",
-	"aws_redshift_cluster_snapshot":                            "Redshift",
-	"aws_ssm_maintenance_window":                               "SSM (Systems Manager)",
-	"aws_timestreamwrite_table":                                "Timestream Write",
-	"aws_autoscaling_attachment":                               "Auto Scaling",
-	"aws_vpc_endpoint_policy":                                  "VPC (Virtual Private Cloud)",
-	"aws_applicationinsights_application":                      "CloudWatch Application Insights",
-	"aws_chime_voice_connector_termination":                    "Chime",
-	"aws_docdb_subnet_group":                                   "DocumentDB",
-	"aws_dx_gateway":                                           "Direct Connect",
-	"aws_waf_geo_match_set":                                    "WAF Classic",
-	"aws_appconfig_hosted_configuration_version":               "AppConfig",
-	"aws_route53recoveryreadiness_cell":                        "Route 53 Recovery Readiness",
-	"aws_route53_resolver_firewall_rule_group_association":     "Route 53 Resolver",
-	"aws_location_geofence_collection":                         "Location",
-	"aws_fsx_lustre_file_system":                               "FSx",
-	"aws_ecrpublic_repository_policy":                          "ECR Public",
-	"aws_ec2_transit_gateway_peering_attachment_accepter":      "Transit Gateway",
-	"aws_glue_dev_endpoint":                                    "Glue",
-	"aws_iot_event_configurations":                             "IoT Core",
-	"aws_memorydb_acl":                                         "MemoryDB for Redis",
-	"aws_waf_xss_match_set":                                    "WAF Classic",
-	"aws_accessanalyzer_analyzer":                              "IAM Access Analyzer",
-	"aws_glue_connection":                                      "Glue",
-	"aws_storagegateway_tape_pool":                             "Storage Gateway",
-	"aws_verifiedaccess_endpoint":                              "Verified Access",
-	"aws_ec2_managed_prefix_list":                              "VPC (Virtual Private Cloud)",
-	"aws_cloud9_environment_membership":                        "Cloud9",
-	"aws_codedeploy_deployment_group":                          "CodeDeploy",
-	"aws_ebs_snapshot":                                         "EBS (EC2)",
-	"aws_ec2_client_vpn_network_association":                   "VPN (Client)",
-	"aws_backup_global_settings":                               "Backup",
-	"aws_flow_log":                                             "VPC (Virtual Private Cloud)",
-	"aws_rds_cluster":                                          "RDS (Relational Database)",
-	"aws_ssoadmin_application_assignment":                      "SSO Admin",
-	"aws_config_aggregate_authorization":                       "Config",
-	"aws_dlm_lifecycle_policy":                                 "DLM (Data Lifecycle Manager)",
-	"aws_lb_trust_store":                                       "ELB (Elastic Load Balancing)",
-	"aws_s3_bucket_logging":                                    "S3 (Simple Storage)",
-	"aws_s3control_access_point_policy":                        "S3 Control",
-	"aws_securityhub_standards_subscription":                   "Security Hub",
-	"aws_db_snapshot_copy":                                     "RDS (Relational Database)",
-	"aws_ebs_volume":                                           "EBS (EC2)",
-	"aws_fsx_ontap_volume":                                     "FSx",
-	"aws_grafana_license_association":                          "Managed Grafana",
-	"aws_kms_custom_key_store":                                 "KMS (Key Management)",
-	"aws_macie2_classification_job":                            "Macie",
-	"aws_route53_query_log":                                    "Route 53",
-	"aws_autoscaling_lifecycle_hook":                           "Auto Scaling",
-	"aws_redshift_authentication_profile":                      "Redshift",
-	"aws_securitylake_data_lake":                               "Security Lake",
-	"aws_sfn_activity":                                         "SFN (Step Functions)",
-	"aws_evidently_segment":                                    "CloudWatch Evidently",
-	"aws_internet_gateway":                                     "VPC (Virtual Private Cloud)",
-	"aws_opensearch_outbound_connection":                       "OpenSearch",
-	"aws_quicksight_folder_membership":                         "QuickSight",
-	"aws_route53_resolver_dnssec_config":                       "Route 53 Resolver",
-	"aws_ses_domain_dkim":                                      "SES (Simple Email)",
-	"aws_default_vpc":                                          "VPC (Virtual Private Cloud)",
-	"aws_security_group_rule":                                  "VPC (Virtual Private Cloud)",
-	"aws_eks_identity_provider_config":                         "EKS (Elastic Kubernetes)",
-	"aws_emr_managed_scaling_policy":                           "EMR",
-	"aws_rekognition_collection":                               "Rekognition",
-	"aws_appsync_resolver":                                     "AppSync",
-	"aws_organizations_resource_policy":                        "Organizations",
-	"aws_quicksight_group":                                     "QuickSight",
-	"aws_bedrockagent_agent":                                   "Bedrock Agents",
-	"aws_glue_catalog_table":                                   "Glue",
-	"aws_storagegateway_cached_iscsi_volume":                   "Storage Gateway",
-	"aws_cloudwatch_log_data_protection_policy":                "CloudWatch Logs",
-	"aws_emr_instance_fleet":                                   "EMR",
-	"aws_kendra_thesaurus":                                     "Kendra",
-	"aws_paymentcryptography_key_alias":                        "Payment Cryptography Control Plane",
-	"aws_ses_domain_identity":                                  "SES (Simple Email)",
-	"aws_sns_sms_preferences":                                  "SNS (Simple Notification)",
-	"aws_ecs_account_setting_default":                          "ECS (Elastic Container)",
-	"aws_redshift_snapshot_copy":                               "Redshift",
-	"aws_s3_bucket_notification":                               "S3 (Simple Storage)",
-	"aws_sagemaker_notebook_instance":                          "SageMaker",
-	"aws_wafregional_geo_match_set":                            "WAF Classic Regional",
-	"aws_workspaces_connection_alias":                          "WorkSpaces",
-	"aws_dms_replication_config":                               "DMS (Database Migration)",
-	"aws_organizations_organizational_unit":                    "Organizations",
-	"aws_route53recoverycontrolconfig_safety_rule":             "Route 53 Recovery Control Config",
-	"aws_auditmanager_framework_share":                         "Audit Manager",
-	"aws_apprunner_vpc_ingress_connection":                     "App Runner",
-	"aws_backup_vault_policy":                                  "Backup",
-	"aws_ce_cost_category":                                     "CE (Cost Explorer)",
-	"aws_detective_invitation_accepter":                        "Detective",
-	"aws_glacier_vault":                                        "S3 Glacier",
-	"aws_globalaccelerator_accelerator":                        "Global Accelerator",
-	"aws_glue_registry":                                        "Glue",
-	"aws_apprunner_observability_configuration":                "App Runner",
-	"aws_worklink_fleet":                                       "WorkLink",
-	"aws_redshift_hsm_configuration":                           "Redshift",
-	"aws_cognito_identity_pool":                                "Cognito Identity",
-	"aws_memorydb_cluster":                                     "MemoryDB for Redis",
-	"aws_neptune_global_cluster":                               "Neptune",
-	"aws_redshift_endpoint_authorization":                      "Redshift",
-	"aws_securityhub_configuration_policy_association":         "Security Hub",
-	"aws_vpc_endpoint":                                         "VPC (Virtual Private Cloud)",
-	"aws_account_region":                                       "Account Management",
-	"aws_elasticache_parameter_group":                          "ElastiCache",
-	"aws_location_tracker_association":                         "Location",
-	"aws_opsworks_user_profile":                                "OpsWorks",
-	"aws_ssoadmin_customer_managed_policy_attachment":          "SSO Admin",
-	"aws_vpn_gateway_route_propagation":                        "VPN (Site-to-Site)",
-	"aws_dx_bgp_peer":                                          "Direct Connect",
-	"aws_iam_group":                                            "IAM (Identity & Access Management)",
-	"aws_kendra_faq":                                           "Kendra",
-	"aws_lightsail_static_ip":                                  "Lightsail",
-	"aws_codepipeline":                                         "CodePipeline",
-	"aws_ec2_transit_gateway_route_table":                      "Transit Gateway",
-	"aws_fsx_openzfs_volume":                                   "FSx",
-	"aws_lightsail_bucket_access_key":                          "Lightsail",
-	"aws_shield_proactive_engagement":                          "Shield",
-	"aws_dynamodb_table_export":                                "DynamoDB",
-	"aws_dataexchange_data_set":                                "Data Exchange",
-	"aws_default_network_acl":                                  "VPC (Virtual Private Cloud)",
-	"aws_iot_topic_rule_destination":                           "IoT Core",
-	"aws_pipes_pipe":                                           "EventBridge Pipes",
-	"aws_route53_resolver_rule":                                "Route 53 Resolver",
-	"aws_service_discovery_http_namespace":                     "Cloud Map",
-	"aws_subnet":                                               "VPC (Virtual Private Cloud)",
-	"aws_acmpca_certificate":                                   "ACM PCA (Certificate Manager Private Certificate Authority)",
-	"aws_lexv2models_bot_version":                              "Lex V2 Models",
-	"aws_opensearchserverless_access_policy":                   "OpenSearch Serverless",
-	"aws_pinpoint_event_stream":                                "Pinpoint",
-	"aws_api_gateway_integration_response":                     "API Gateway",
-	"aws_eip_association":                                      "EC2 (Elastic Compute Cloud)",
-	"aws_gamelift_game_session_queue":                          "GameLift",
-	"aws_organizations_account":                                "Organizations",
-	"aws_s3_bucket_metric":                                     "S3 (Simple Storage)",
-	"aws_amplify_webhook":                                      "Amplify",
-	"aws_datasync_task":                                        "DataSync",
-	"aws_ecs_tag":                                              "ECS (Elastic Container)",
-	"aws_efs_file_system_policy":                               "EFS (Elastic File System)",
-	"aws_guardduty_detector_feature":                           "GuardDuty",
-	"aws_kms_key_policy":                                       "KMS (Key Management)",
-	"aws_media_package_channel":                                "Elemental MediaPackage",
-	"aws_cognito_user_pool":                                    "Cognito IDP (Identity Provider)",
-	"aws_lambda_function_event_invoke_config":                  "Lambda",
-	"aws_licensemanager_grant_accepter":                        "License Manager",
-	"aws_route53_traffic_policy_instance":                      "Route 53",
-	"aws_ssm_default_patch_baseline":                           "SSM (Systems Manager)",
-	"aws_vpc_endpoint_service_allowed_principal":               "VPC (Virtual Private Cloud)",
-	"aws_ecs_service":                                          "ECS (Elastic Container)",
-	"aws_api_gateway_documentation_version":                    "API Gateway",
-	"aws_cognito_user_pool_domain":                             "Cognito IDP (Identity Provider)",
-	"aws_lambda_permission":                                    "Lambda",
-	"aws_load_balancer_backend_server_policy":                  "ELB Classic",
-	"aws_s3_bucket_replication_configuration":                  "S3 (Simple Storage)",
-	"aws_s3_bucket_server_side_encryption_configuration":       "S3 (Simple Storage)",
-	"aws_waf_rule_group":                                       "WAF Classic",
-	"aws_api_gateway_api_key":                                  "API Gateway",
-	"aws_lightsail_bucket_resource_access":                     "Lightsail",
-	"aws_ec2_transit_gateway_connect_peer":                     "Transit Gateway",
-	"aws_fms_admin_account":                                    "FMS (Firewall Manager)",
-	"aws_s3_bucket_versioning":                                 "S3 (Simple Storage)",
-	"aws_vpclattice_access_log_subscription":                   "VPC Lattice",
-	"aws_ce_anomaly_subscription":                              "CE (Cost Explorer)",
-	"aws_connect_user":                                         "Connect",
-	"aws_devicefarm_instance_profile":                          "Device Farm",
-	"aws_ebs_default_kms_key":                                  "EBS (EC2)",
-	"aws_evidently_feature":                                    "CloudWatch Evidently",
-	"aws_globalaccelerator_endpoint_group":                     "Global Accelerator",
-	"aws_route53_traffic_policy":                               "Route 53",
-	"aws_s3_access_point":                                      "S3 Control",
-	"aws_bedrock_provisioned_model_throughput":                 "Bedrock",
-	"aws_verifiedaccess_trust_provider":                        "Verified Access",
-	"aws_vpc_ipv6_cidr_block_association":                      "VPC (Virtual Private Cloud)",
-	"aws_wafv2_web_acl":                                        "WAF",
-	"aws_spot_fleet_request":                                   "EC2 (Elastic Compute Cloud)",
-	"aws_ec2_availability_zone_group":                          "EC2 (Elastic Compute Cloud)",
-	"aws_finspace_kx_dataview":                                 "FinSpace",
-	"aws_s3control_access_grant":                               "S3 Control",
-	"aws_sesv2_dedicated_ip_pool":                              "SESv2 (Simple Email V2)",
-	"aws_directory_service_trust":                              "Directory Service",
-	"aws_config_remediation_configuration":                     "Config",
-	"aws_lb_listener":                                          "ELB (Elastic Load Balancing)",
-	"aws_mq_configuration":                                     "MQ",
-	"aws_redshift_partner":                                     "Redshift",
-	"aws_servicecatalog_tag_option_resource_association":       "Service Catalog",
-	"aws_codecatalyst_project":                                 "CodeCatalyst",
-	"aws_rbin_rule":                                            "Recycle Bin (RBin)",
-	"aws_route53recoverycontrolconfig_routing_control":         "Route 53 Recovery Control Config",
-	"aws_route_table":                                          "VPC (Virtual Private Cloud)",
-	"aws_sagemaker_pipeline":                                   "SageMaker",
-	"aws_servicecatalog_provisioned_product":                   "Service Catalog",
-	"aws_servicecatalog_provisioning_artifact":                 "Service Catalog",
-	"aws_kinesis_video_stream":                                 "Kinesis Video",
-	"aws_datasync_location_s3":                                 "DataSync",
-	"aws_emrcontainers_job_template":                           "EMR Containers",
-	"aws_opensearch_inbound_connection_accepter":               "OpenSearch",
-	"aws_ses_configuration_set":                                "SES (Simple Email)",
-	"aws_vpclattice_service_network":                           "VPC Lattice",
-	"aws_bcmdataexports_export":                                "BCM Data Exports",
-	"aws_iam_openid_connect_provider":                          "IAM (Identity & Access Management)",
-	"aws_route53recoveryreadiness_resource_set":                "Route 53 Recovery Readiness",
-	"aws_s3_bucket_accelerate_configuration":                   "S3 (Simple Storage)",
-	"aws_cloudformation_stack_set_instance":                    "CloudFormation",
-	"aws_cloudwatch_log_resource_policy":                       "CloudWatch Logs",
-	"aws_codecommit_approval_rule_template":                    "CodeCommit",
-	"aws_detective_graph":                                      "Detective",
-	"aws_networkmanager_connect_peer":                          "Network Manager",
-	"aws_rds_cluster_endpoint":                                 "RDS (Relational Database)",
-	"aws_rekognition_project":                                  "Rekognition",
-	"aws_apigatewayv2_vpc_link":                                "API Gateway V2",
-	"aws_codebuild_report_group":                               "CodeBuild",
-	"aws_db_instance_automated_backups_replication":            "RDS (Relational Database)",
-	"aws_devicefarm_device_pool":                               "Device Farm",
-	"aws_kms_replica_key":                                      "KMS (Key Management)",
-	"aws_lambda_runtime_management_config":                     "Lambda",
-	"aws_cloudfront_field_level_encryption_profile":            "CloudFront",
-	"aws_directory_service_shared_directory_accepter":          "Directory Service",
-	"aws_memorydb_parameter_group":                             "MemoryDB for Redis",
-	"aws_msk_scram_secret_association":                         "Managed Streaming for Kafka",
-	"aws_neptune_event_subscription":                           "Neptune",
-	"aws_opsworks_java_app_layer":                              "OpsWorks",
-	"aws_opsworks_static_web_layer":                            "OpsWorks",
-	"aws_pinpoint_apns_sandbox_channel":                        "Pinpoint",
-	"aws_chime_voice_connector_group":                          "Chime",
-	"aws_iam_server_certificate":                               "IAM (Identity & Access Management)",
-	"aws_media_store_container":                                "Elemental MediaStore",
-	"aws_s3_bucket_acl":                                        "S3 (Simple Storage)",
-	"aws_vpc":                                                  "VPC (Virtual Private Cloud)",
-	"aws_workspaces_ip_group":                                  "WorkSpaces",
-	"aws_dms_replication_task":                                 "DMS (Database Migration)",
-	"aws_appautoscaling_policy":                                "Application Auto Scaling",
-	"aws_bedrockagent_agent_alias":                             "Bedrock Agents",
-	"aws_elasticsearch_vpc_endpoint":                           "Elasticsearch",
-	"aws_kendra_query_suggestions_block_list":                  "Kendra",
-	"aws_kms_key":                                              "KMS (Key Management)",
-	"aws_ssoadmin_application_assignment_configuration":        "SSO Admin",
-	"aws_ssoadmin_managed_policy_attachment":                   "SSO Admin",
-	"aws_api_gateway_method_settings":                          "API Gateway",
-	"aws_ssoadmin_trusted_token_issuer":                        "SSO Admin",
-	"aws_codepipeline_webhook":                                 "CodePipeline",
-	"aws_iam_role_policy_attachment":                           "IAM (Identity & Access Management)",
-	"aws_secretsmanager_secret_rotation":                       "Secrets Manager",
-	"aws_appstream_image_builder":                              "AppStream 2.0",
-	"aws_fms_policy":                                           "FMS (Firewall Manager)",
-	"aws_glue_partition":                                       "Glue",
-	"aws_lex_intent":                                           "Lex Model Building",
-	"aws_lightsail_bucket":                                     "Lightsail",
-	"aws_scheduler_schedule":                                   "EventBridge Scheduler",
-	"aws_dx_hosted_transit_virtual_interface":                  "Direct Connect",
-	"aws_auditmanager_organization_admin_account_registration": "Audit Manager",
-	"aws_cloudcontrolapi_resource":                             "Cloud Control API",
-	"aws_config_config_rule":                                   "Config",
-	"aws_elasticsearch_domain_saml_options":                    "Elasticsearch",
-	"aws_lightsail_disk":                                       "Lightsail",
-	"aws_resourcegroups_resource":                              "Resource Groups",
-	"aws_appstream_user_stack_association":                     "AppStream 2.0",
-	"aws_ivs_recording_configuration":                          "IVS (Interactive Video)",
-	"aws_ses_email_identity":                                   "SES (Simple Email)",
-	"aws_xray_group":                                           "X-Ray",
-	"aws_ivs_playback_key_pair":                                "IVS (Interactive Video)",
-	"aws_account_primary_contact":                              "Account Management",
-	"aws_iam_user_group_membership":                            "IAM (Identity & Access Management)",
-	"aws_sagemaker_feature_group":                              "SageMaker",
-	"aws_account_alternate_contact":                            "Account Management",
-	"aws_lambda_alias":                                         "Lambda",
-	"aws_wafv2_web_acl_logging_configuration":                  "WAF",
-	"aws_db_snapshot":                                          "RDS (Relational Database)",
-	"aws_glue_crawler":                                         "Glue",
-	"aws_lexv2models_slot_type":                                "Lex V2 Models",
-	"aws_appconfig_extension":                                  "AppConfig",
-	"aws_eks_addon":                                            "EKS (Elastic Kubernetes)",
-	"aws_network_interface_attachment":                         "VPC (Virtual Private Cloud)",
-	"aws_redshift_hsm_client_certificate":                      "Redshift",
-	"aws_resourceexplorer2_view":                               "Resource Explorer",
-	"aws_securityhub_product_subscription":                     "Security Hub",
-	"aws_cognito_managed_user_pool_client":                     "Cognito IDP (Identity Provider)",
-	"aws_connect_routing_profile":                              "Connect",
-	"aws_securitylake_custom_log_source":                       "Security Lake",
-	"aws_waf_regex_match_set":                                  "WAF Classic",
-	"aws_connect_bot_association":                              "Connect",
-	"aws_api_gateway_account":                                  "API Gateway",
-	"aws_efs_access_point":                                     "EFS (Elastic File System)",
-	"aws_elasticache_serverless_cache":                         "ElastiCache",
-	"aws_networkmanager_connection":                            "Network Manager",
-	"aws_servicecatalog_product":                               "Service Catalog",
-	"aws_ami":                                                  "EC2 (Elastic Compute Cloud)",
-	"aws_finspace_kx_cluster":                                  "FinSpace",
-	"aws_iot_indexing_configuration":                           "IoT Core",
-	"aws_s3control_bucket_policy":                              "S3 Control",
-	"aws_waf_size_constraint_set":                              "WAF Classic",
-	"aws_ecr_lifecycle_policy":                                 "ECR (Elastic Container Registry)",
-	"aws_cloudfront_field_level_encryption_config":             "CloudFront",
-	"aws_comprehend_entity_recognizer":                         "Comprehend",
-	"aws_datasync_location_fsx_openzfs_file_system":            "DataSync",
-	"aws_devicefarm_project":                                   "Device Farm",
-	"aws_imagebuilder_infrastructure_configuration":            "EC2 Image Builder",
-	"aws_lakeformation_resource_lf_tags":                       "Lake Formation",
-	"aws_networkmanager_attachment_accepter":                   "Network Manager",
-	"aws_ce_cost_allocation_tag":                               "CE (Cost Explorer)",
-	"aws_sagemaker_space":                                      "SageMaker",
-	"aws_cloudwatch_log_subscription_filter":                   "CloudWatch Logs",
-	"aws_connect_security_profile":                             "Connect",
-	"aws_ec2_traffic_mirror_target":                            "VPC (Virtual Private Cloud)",
-	"aws_imagebuilder_image":                                   "EC2 Image Builder",
-	"aws_mskconnect_worker_configuration":                      "Managed Streaming for Kafka Connect",
-	"aws_s3_bucket_policy":                                     "S3 (Simple Storage)",
-	"aws_vpclattice_target_group":                              "VPC Lattice",
-	"aws_autoscaling_group":                                    "Auto Scaling",
-	"aws_route53_resolver_endpoint":                            "Route 53 Resolver",
-	"aws_sagemaker_monitoring_schedule":                        "SageMaker",
-	"aws_batch_job_definition":                                 "Batch",
-	"aws_memorydb_user":                                        "MemoryDB for Redis",
-	"aws_vpc_security_group_egress_rule":                       "VPC (Virtual Private Cloud)",
-	"aws_workspaces_directory":                                 "WorkSpaces",
-	"aws_ec2_transit_gateway_connect":                          "Transit Gateway",
-	"aws_cloudwatch_event_endpoint":                            "EventBridge",
-	"aws_iam_role":                                             "IAM (Identity & Access Management)",
-	"aws_iam_service_linked_role":                              "IAM (Identity & Access Management)",
-	"aws_kinesisanalyticsv2_application_snapshot":              "Kinesis Analytics V2",
-	"aws_s3control_bucket_lifecycle_configuration":             "S3 Control",
-	"aws_securityhub_automation_rule":                          "Security Hub",
-	"aws_sesv2_email_identity_feedback_attributes":             "SESv2 (Simple Email V2)",
-	"aws_apigatewayv2_integration":                             "API Gateway V2",
-	"aws_storagegateway_gateway":                               "Storage Gateway",
-	"aws_transfer_profile":                                     "Transfer Family",
-	"aws_vpc_ipam_preview_next_cidr":                           "VPC IPAM (IP Address Manager)",
-	"aws_ssoadmin_application_access_scope":                    "SSO Admin",
-	"aws_prometheus_alert_manager_definition":                  "AMP (Managed Prometheus)",
-	"aws_service_discovery_service":                            "Cloud Map",
-	"aws_ssoadmin_permissions_boundary_attachment":             "SSO Admin",
-	"aws_dynamodb_global_table":                                "DynamoDB",
-	"aws_ebs_fast_snapshot_restore":                            "EBS (EC2)",
-	"aws_lightsail_database":                                   "Lightsail",
-	"aws_serverlessapplicationrepository_cloudformation_stack": "Serverless Application Repository",
-	"aws_api_gateway_base_path_mapping":                        "API Gateway",
-	"aws_codebuild_project":                                    "CodeBuild",
-	"aws_iot_role_alias":                                       "IoT Core",
-	"aws_cloudwatch_metric_stream":                             "CloudWatch",
-	"aws_finspace_kx_volume":                                   "FinSpace",
-	"aws_lambda_code_signing_config":                           "Lambda",
-	"aws_sagemaker_model":                                      "SageMaker",
-	"aws_shield_drt_access_log_bucket_association":             "Shield",
-	"aws_ec2_transit_gateway_vpc_attachment_accepter":          "Transit Gateway",
-	"aws_config_configuration_recorder_status":                 "Config",
-	"aws_customerprofiles_profile":                             "Connect Customer Profiles",
-	"aws_directory_service_radius_settings":                    "Directory Service",
-	"aws_emr_instance_group":                                   "EMR",
-	"aws_fsx_windows_file_system":                              "FSx",
-	"aws_quicksight_data_source":                               "QuickSight",
-	"aws_securityhub_insight":                                  "Security Hub",
-	"aws_cloudwatch_dashboard":                                 "CloudWatch",
-	"aws_sns_topic_subscription":                               "SNS (Simple Notification)",
-	"aws_vpclattice_listener_rule":                             "VPC Lattice",
-	"aws_securitylake_subscriber":                              "Security Lake",
-	"aws_ec2_subnet_cidr_reservation":                          "VPC (Virtual Private Cloud)",
-	"aws_elb":                                                  "ELB Classic",
-	"aws_guardduty_organization_configuration":                 "GuardDuty",
-	"aws_instance":                                             "EC2 (Elastic Compute Cloud)",
-	"aws_prometheus_workspace":                                 "AMP (Managed Prometheus)",
-	"aws_transcribe_vocabulary":                                "Transcribe",
-	"aws_cloudfront_public_key":                                "CloudFront",
-	"aws_egress_only_internet_gateway":                         "VPC (Virtual Private Cloud)",
-	"aws_verifiedpermissions_policy_store":                     "Verified Permissions",
-	"aws_apigatewayv2_stage":                                   "API Gateway V2",
-	"aws_cloudfront_origin_request_policy":                     "CloudFront",
-	"aws_eip_domain_name":                                      "EC2 (Elastic Compute Cloud)",
-	"aws_rolesanywhere_profile":                                "Roles Anywhere",
-	"aws_sesv2_email_identity_mail_from_attributes":            "SESv2 (Simple Email V2)",
-	"aws_apprunner_custom_domain_association":                  "App Runner",
-	"aws_macie2_organization_admin_account":                    "Macie",
-	"aws_db_proxy":                                             "RDS (Relational Database)",
-	"aws_guardduty_member":                                     "GuardDuty",
-	"aws_quicksight_template_alias":                            "QuickSight",
-	"aws_cur_report_definition":                                "Cost and Usage Report",
-	"aws_cloudwatch_event_bus":                                 "EventBridge",
-	"aws_codecommit_trigger":                                   "CodeCommit",
-	"aws_datasync_location_hdfs":                               "DataSync",
-	"aws_ec2_transit_gateway_peering_attachment":               "Transit Gateway",
-	"aws_iot_policy_attachment":                                "IoT Core",
-	"aws_s3control_access_grants_instance_resource_policy":     "S3 Control",
-	"aws_verifiedaccess_instance_logging_configuration":        "Verified Access",
-	"aws_apigatewayv2_integration_response":                    "API Gateway V2",
-	"aws_wafv2_web_acl_association":                            "WAF",
-	"aws_vpc_endpoint_private_dns":                             "VPC (Virtual Private Cloud)",
-	"aws_chime_voice_connector":                                "Chime",
-	"aws_codedeploy_deployment_config":                         "CodeDeploy",
-	"aws_lightsail_lb_stickiness_policy":                       "Lightsail",
-	"aws_organizations_organization":                           "Organizations",
-	"aws_sagemaker_app_image_config":                           "SageMaker",
-	"aws_ssoadmin_permission_set":                              "SSO Admin",
-	"aws_swf_domain":                                           "SWF (Simple Workflow)",
-	"aws_api_gateway_deployment":                               "API Gateway",
-	"aws_customerprofiles_domain":                              "Connect Customer Profiles",
-	"aws_route53_delegation_set":                               "Route 53",
-	"aws_apigatewayv2_api_mapping":                             "API Gateway V2",
-	"aws_lambda_provisioned_concurrency_config":                "Lambda",
-	"aws_schemas_registry_policy":                              "EventBridge Schemas",
-	"aws_storagegateway_nfs_file_share":                        "Storage Gateway",
-	"aws_db_proxy_target":                                      "RDS (Relational Database)",
-	"aws_config_conformance_pack":                              "Config",
-	"aws_dx_gateway_association":                               "Direct Connect",
-	"aws_efs_mount_target":                                     "EFS (Elastic File System)",
-	"aws_emr_studio":                                           "EMR",
-	"aws_sesv2_account_vdm_attributes":                         "SESv2 (Simple Email V2)",
-	"aws_vpc_ipam_pool_cidr":                                   "VPC IPAM (IP Address Manager)",
-	"aws_autoscaling_policy":                                   "Auto Scaling",
-	"aws_storagegateway_working_storage":                       "Storage Gateway",
-	"aws_lexv2models_bot_locale":                               "Lex V2 Models",
-	"aws_sfn_state_machine":                                    "SFN (Step Functions)",
-	"aws_eks_pod_identity_association":                         "EKS (Elastic Kubernetes)",
-	"aws_glue_schema":                                          "Glue",
-	"aws_guardduty_filter":                                     "GuardDuty",
-	"aws_neptune_cluster_endpoint":                             "Neptune",
-	"aws_opsworks_application":                                 "OpsWorks",
-	"aws_opsworks_ganglia_layer":                               "OpsWorks",
-	"aws_opsworks_rails_app_layer":                             "OpsWorks",
-	"aws_organizations_delegated_administrator":                "Organizations",
-	"aws_docdb_cluster":                                        "DocumentDB",
-	"aws_xray_sampling_rule":                                   "X-Ray",
-	"aws_ses_active_receipt_rule_set":                          "SES (Simple Email)",
-}
@@ -1,144 +1,124 @@
 package utils
 
 import (
-	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
+	"path"
+	"sort"
+	"sync"
+)
 
-	"io/fs"
-	"regexp"
+// PACCoverage reports the resources of the IaC the fetched policies cannot cover.
+type PACCoverage struct {
+	// UnmappedResourceTypes are the cloud resource types without a taxon.
+	UnmappedResourceTypes []string
+	// MissingTaxons are the taxons without a directory in the PAC repository,
+	// as <subdirectory>/<taxon>.
+	MissingTaxons []string
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-)
+	// taxonomy selected the policies, nil when they were not fetched
+	taxonomy *Taxonomy
+}
 
-func getTaxons(resources []string) map[string]string {
-	taxons := map[string]string{}
-	for _, resource := range resources {
-		tfName, ok := TerraformResourceToTerraformName[resource]
-		if ok {
-			if taxon, ok := terraformToTaxonName[tfName]; ok {
-				taxons[taxon.CloudServiceName] = "DEFAULT"
-			}
+// defaultTaxonomyOnce is a shared, read-only instance of the default taxonomy.
+var defaultTaxonomyOnce = sync.OnceValues(DefaultTaxonomy)
+
+// IsCloudResource reports whether policies are expected for the resource type,
+// according to the taxonomy the policies were selected with or the default one.
+func (c PACCoverage) IsCloudResource(resourceType string) bool {
+	taxonomy := c.taxonomy
+	if taxonomy == nil {
+		var err error
+		if taxonomy, err = defaultTaxonomyOnce(); err != nil {
+			return false
 		}
 	}
-	return taxons
+	return taxonomy.IsCloudResource(resourceType)
 }
 
-func getResources(iacPath string) ([]string, error) {
-	// Set to store unique resource types
-	resourceTypes := make(map[string]struct{})
-
-	// Regex to match resource blocks in Terraform files
-	resourceRegex := regexp.MustCompile(`resource\s+"([^"]+)"\s+"([^"]+)"`)
-
-	// Walk through all files in the directory
-	err := filepath.Walk(iacPath, func(path string, info fs.FileInfo, err error) error {
-		// Check for errors during walking
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Only process .tf files
-		if !strings.HasSuffix(path, ".tf") {
-			return nil
-		}
-
-		// Read file contents
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("error reading file %s: %v", path, err)
-		}
-
-		// Find all resource matches in the file
-		matches := resourceRegex.FindAllStringSubmatch(string(content), -1)
-		for _, match := range matches {
-			if len(match) >= 2 {
-				resourceTypes[match[1]] = struct{}{}
-			}
-		}
-
-		return nil
-	})
-
-	// Check for errors during file walking
-	if err != nil {
-		return nil, fmt.Errorf("error walking directory: %v", err)
+// resourceTypes returns the distinct types of the resources.
+func resourceTypes(resources []TerraformResource) []string {
+	resourceTypes := map[string]struct{}{}
+	for _, resource := range resources {
+		resourceTypes[resource.Type] = struct{}{}
 	}
-
-	// Convert map keys to slice
 	result := make([]string, 0, len(resourceTypes))
 	for resourceType := range resourceTypes {
 		result = append(result, resourceType)
 	}
-
-	return result, nil
+	return result
 }
 
-func getTaxonsByIAC(iacPath string) ([]string, error) {
-	resources, err := getResources(iacPath)
+// GetDefaultPAC fetches the policies of source relevant to the resources of
+// the IaC, see LoadIaC, into a temporary directory and returns it with a
+// function removing it. The policies of each cloud are read from its own
+// folder of the repository. The coverage lists the resource types and taxons
+// no policy was found for.
+func GetDefaultPAC(iacResources []TerraformResource, source PACSource) (string, PACCoverage, func(), error) {
+	noop := func() {}
+	coverage := PACCoverage{MissingTaxons: []string{}}
+	resources := resourceTypes(iacResources)
+
+	cache, err := OpenPACCache(source.Cache)
 	if err != nil {
-		return nil, err
+		return "", coverage, noop, &PACFetchError{Source: source.repository(), Err: err}
 	}
-
-	result := []string{}
-	taxons := getTaxons(resources)
-	for taxon := range taxons {
-		result = append(result, taxon)
-	}
-	return result, nil
-}
-
-func GetDefaultPAC(iacPath, pacVersion string) (string, error) {
-	taxons, err := getTaxonsByIAC(iacPath)
+	checkout, err := getPACPath(cache, source)
 	if err != nil {
-		return "", err
+		return "", coverage, noop, err
 	}
-
-	tempCloneDir, err := os.MkdirTemp("", "pac-clone-*")
+	taxons := checkout.taxonomy.cloudTaxons(resources)
+	coverage.UnmappedResourceTypes = checkout.taxonomy.unmappedResourceTypes(resources)
+	coverage.taxonomy = checkout.taxonomy
+
+	clouds := SortedKeys(taxons)
+	if len(clouds) == 0 {
+		// Without known resources the aws folder is still fetched and verified
+		clouds = []string{CloudAWS}
+	}
+	folders, err := checkout.folders(source, clouds...)
 	if err != nil {
-		return "", fmt.Errorf("failed to create temporary directory: %v", err)
+		return "", coverage, noop, err
 	}
 
-	tempPACPath, err := getPACPath(tempCloneDir, pacVersion, taxons)
+	outputDir, err := os.MkdirTemp("", "pac-rules-*")
 	if err != nil {
-		return "", err
+		return "", coverage, noop, &PACFetchError{Source: "temporary directory", Err: err}
 	}
+	cleanup := func() { os.RemoveAll(outputDir) }
 
-	relevantPACPath, err := extractRegoFiles(tempPACPath, taxons)
+	extraction, err := newRegoExtraction(checkout.path, outputDir)
 	if err != nil {
-		return "", err
+		cleanup()
+		return "", coverage, noop, &PACFetchError{Source: outputDir, Err: err}
 	}
-	return relevantPACPath, nil
-}
-
-func getPACPath(tempDir string, branch string, taxons []string) (string, error) {
-	repoURL := "https://github.com/nonfx/starchitect-cloudguard"
-	folderPath := "terraform/aws"
-	if branch == "" {
-		branch = "main"
+	for _, cloud := range clouds {
+		missing := taxons[cloud]
+		if folder, ok := folders[cloud]; ok {
+			missing, err = extraction.extractTaxons(folder, taxons[cloud])
+			if err != nil {
+				cleanup()
+				return "", coverage, noop, &PACFetchError{Source: folder, Err: err}
+			}
+		}
+		for _, taxon := range missing {
+			coverage.MissingTaxons = append(coverage.MissingTaxons, path.Join(source.cloudSubdirectory(checkout.taxonomy, cloud), taxon))
+		}
 	}
+	sort.Strings(coverage.MissingTaxons)
 
-	_, err := git.PlainClone(tempDir, false, &git.CloneOptions{
-		URL:           repoURL,
-		Progress:      os.Stdout,
-		Depth:         1,
-		ReferenceName: plumbing.NewBranchReferenceName(branch),
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to clone rules repository: %v", err)
+	if err := extraction.extractLibraries(); err != nil {
+		cleanup()
+		return "", coverage, noop, &PACFetchError{Source: checkout.path, Err: err}
 	}
-
-	// Step 3: Construct the path to the desired folder
-	clonedFolderPath := filepath.Join(tempDir, folderPath)
-	if _, err := os.Stat(clonedFolderPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("folder %s does not exist in the cloned repository", clonedFolderPath)
+	// The libraries are extracted from anywhere in the checkout, outside of
+	// the policy folders verified so far
+	if err := source.Verification.verify(checkout.path, checkout.commit, extraction.sourcePaths()...); err != nil {
+		cleanup()
+		return "", coverage, noop, &PACVerificationError{Source: source.repository(), Err: err}
+	}
+	if err := extraction.checkCollisions(); err != nil {
+		cleanup()
+		return "", coverage, noop, err
 	}
-	return clonedFolderPath, nil
+	return outputDir, coverage, cleanup, nil
 }
@@ -5,65 +5,41 @@ import (
 	"testing"
 )
 
-func Test_getTaxonsByIAC(t *testing.T) {
-	type args struct {
-		iacPath string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []string
-		wantErr bool
-	}{
-		{
-			name: "success",
-			args: args{
-				iacPath: "../testdata/valid_iac",
-			},
-			want: []string{},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := getTaxonsByIAC(tt.args.iacPath)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("getTaxonsByIAC() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("getTaxonsByIAC() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGetDefaultPAC(t *testing.T) {
-	type args struct {
-		iacPath    string
-		pacVersion string
-	}
+	fixture := newPACRepoFixture(t)
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name        string
+		iacPath     string
+		wantRules   []string
+		wantMissing []string
 	}{
 		{
-			name: "success",
-			args: args{
-				iacPath: "../testdata/valid_iac",
+			name:    "success",
+			iacPath: "../../testdata/valid_iac",
+			wantRules: []string{
+				"terraform/aws/Amazon Elastic Compute Cloud (EC2)/ec2_v1.rego",
+			},
+			wantMissing: []string{
+				"terraform/aws/AWS Auto Scaling",
+				"terraform/aws/Amazon Identity and Access Management",
+				"terraform/aws/Amazon Relational Database Service",
+				"terraform/aws/Amazon Virtual Private Cloud",
+				"terraform/aws/Elastic Load Balancing",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetDefaultPAC(tt.args.iacPath, tt.args.pacVersion)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetDefaultPAC() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			got, coverage, cleanup, err := GetDefaultPAC(mustLoadIaC(t, tt.iacPath), PACSource{Repository: fixture.url, Cache: PACCacheOptions{Dir: t.TempDir()}})
+			if err != nil {
+				t.Fatalf("GetDefaultPAC() error = %v", err)
+			}
+			defer cleanup()
+			if rules := extractedFiles(t, got); !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("GetDefaultPAC() extracted %v, want %v", rules, tt.wantRules)
 			}
-			if got != tt.want {
-				t.Errorf("GetDefaultPAC() = %v, want %v", got, tt.want)
+			if !reflect.DeepEqual(coverage.MissingTaxons, tt.wantMissing) {
+				t.Errorf("GetDefaultPAC() missing taxons = %v, want %v", coverage.MissingTaxons, tt.wantMissing)
 			}
 		})
 	}
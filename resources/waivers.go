@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// Waiver accepts the risk of a failing rule, optionally for matching resources only.
+type Waiver struct {
+	RuleID string
+	// ResourceID is a resource address or a path.Match glob of the waived
+	// resource IDs, empty matches every resource.
+	ResourceID string
+	Reason     string
+	Owner      string
+	// Expires is the moment the waiver stops applying, zero never expires.
+	Expires time.Time
+}
+
+// WaiverStatus reports how a configured waiver was applied.
+type WaiverStatus struct {
+	Waiver
+	// Matched is the number of FAIL results marked as WAIVED by the waiver.
+	Matched int
+	Expired bool
+}
+
+// parseWaiverExpiry accepts a date (YYYY-MM-DD), valid through the end of
+// that day in UTC, or an RFC 3339 timestamp.
+func parseWaiverExpiry(expires string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, expires); err == nil {
+		return date.Add(24 * time.Hour), nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date (YYYY-MM-DD) or RFC 3339 timestamp, got %q", expires)
+	}
+	return timestamp, nil
+}
+
+func (w Waiver) expired(now time.Time) bool {
+	return !w.Expires.IsZero() && !now.Before(w.Expires)
+}
+
+func (w Waiver) matches(rule RegulaRuleResult) bool {
+	if w.RuleID != rule.RuleID {
+		return false
+	}
+	// Addresses such as aws_instance.web[0] match literally, their brackets
+	// would be read as character classes by path.Match
+	if w.ResourceID == "" || w.ResourceID == rule.ResourceID {
+		return true
+	}
+	matched, err := path.Match(w.ResourceID, rule.ResourceID)
+	return err == nil && matched
+}
+
+// validateWaiverResourceID rejects resource_id patterns path.Match cannot parse.
+func validateWaiverResourceID(resourceID string) error {
+	if _, err := path.Match(resourceID, ""); err != nil {
+		return fmt.Errorf("%q is not a valid resource address or glob: %v", resourceID, err)
+	}
+	return nil
+}
+
+// applyWaivers marks the FAIL results matched by an unexpired waiver as WAIVED.
+func applyWaivers(regulaOutput *RegulaOutput, waivers []Waiver, now time.Time) []WaiverStatus {
+	statuses := make([]WaiverStatus, 0, len(waivers))
+	for _, waiver := range waivers {
+		statuses = append(statuses, WaiverStatus{Waiver: waiver, Expired: waiver.expired(now)})
+	}
+
+	for i, rule := range regulaOutput.RuleResults {
+		if rule.RuleResult != "FAIL" {
+			continue
+		}
+		for j := range statuses {
+			if statuses[j].Expired || !statuses[j].matches(rule) {
+				continue
+			}
+			waiver := statuses[j].Waiver
+			regulaOutput.RuleResults[i].RuleResult = "WAIVED"
+			regulaOutput.RuleResults[i].Waiver = &waiver
+			statuses[j].Matched++
+			break
+		}
+	}
+	return statuses
+}
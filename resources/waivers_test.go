@@ -0,0 +1,129 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApplyWaivers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	regulaOutput := RegulaOutput{RuleResults: []RegulaRuleResult{
+		{RuleID: "2.1.2", RuleResult: "FAIL", ResourceID: "module.ec2.aws_ami.app"},
+		{RuleID: "2.1.2", RuleResult: "FAIL", ResourceID: "aws_ami.base"},
+		{RuleID: "2.1.2", RuleResult: "PASS", ResourceID: "module.ec2.aws_ami.web"},
+		{RuleID: "2.1.5", RuleResult: "FAIL", ResourceID: "aws_ami.base"},
+	}}
+	waivers := []Waiver{
+		{RuleID: "2.1.2", ResourceID: "module.ec2.*", Reason: "legacy AMI"},
+		{RuleID: "2.1.5", Reason: "expired", Expires: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{RuleID: "AutoScaling.1", Reason: "stale"},
+	}
+
+	statuses := applyWaivers(&regulaOutput, waivers, now)
+
+	wantResults := []string{"WAIVED", "FAIL", "PASS", "FAIL"}
+	for i, want := range wantResults {
+		if got := regulaOutput.RuleResults[i].RuleResult; got != want {
+			t.Errorf("applyWaivers() result %d = %s, want %s", i, got, want)
+		}
+	}
+	if regulaOutput.RuleResults[0].Waiver == nil || regulaOutput.RuleResults[0].Waiver.Reason != "legacy AMI" {
+		t.Errorf("applyWaivers() did not record the waiver on the waived result")
+	}
+
+	wantStatuses := []struct {
+		matched int
+		expired bool
+	}{{1, false}, {0, true}, {0, false}}
+	for i, want := range wantStatuses {
+		if statuses[i].Matched != want.matched || statuses[i].Expired != want.expired {
+			t.Errorf("applyWaivers() status %d = %+v, want %+v", i, statuses[i], want)
+		}
+	}
+
+	score := calculateScore(regulaOutput, ScoringModel{})
+	if score.Waived != 1 || score.Failed != 2 {
+		t.Errorf("calculateScore() after waivers = %+v", score)
+	}
+	summary := formatRegulaOutput(regulaOutput, RuleFilter{})
+	if !strings.Contains(summary, "WAIVED: 1") || !strings.Contains(summary, "Waived Results:") || !strings.Contains(summary, "Waiver Reason: legacy AMI") {
+		t.Errorf("formatRegulaOutput() does not list the waived result separately:\n%s", summary)
+	}
+}
+
+func TestParseWaiverExpiry(t *testing.T) {
+	tests := []struct {
+		expires string
+		want    time.Time
+		wantErr bool
+	}{
+		{expires: "2024-06-01", want: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{expires: "2024-06-01T10:00:00Z", want: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)},
+		{expires: "next week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expires, func(t *testing.T) {
+			got, err := parseWaiverExpiry(tt.expires)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWaiverExpiry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseWaiverExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyWaiversIndexedAddress(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	regulaOutput := RegulaOutput{RuleResults: []RegulaRuleResult{
+		{RuleID: "EC2.1", RuleResult: "FAIL", ResourceID: `aws_subnet.private["a"]`},
+		{RuleID: "EC2.1", RuleResult: "FAIL", ResourceID: "aws_instance.web[0]"},
+		{RuleID: "EC2.1", RuleResult: "FAIL", ResourceID: "aws_instance.web[1]"},
+		{RuleID: "EC2.1", RuleResult: "FAIL", ResourceID: `module.vpc.aws_subnet.public["b"]`},
+	}}
+	waivers := []Waiver{
+		{RuleID: "EC2.1", ResourceID: `aws_subnet.private["a"]`, Reason: "literal for_each key"},
+		{RuleID: "EC2.1", ResourceID: "aws_instance.web[0]", Reason: "literal count index"},
+		{RuleID: "EC2.1", ResourceID: "module.vpc.*", Reason: "glob"},
+	}
+
+	statuses := applyWaivers(&regulaOutput, waivers, now)
+
+	for i, want := range []string{"WAIVED", "WAIVED", "FAIL", "WAIVED"} {
+		if got := regulaOutput.RuleResults[i].RuleResult; got != want {
+			t.Errorf("applyWaivers() result %s = %s, want %s", regulaOutput.RuleResults[i].ResourceID, got, want)
+		}
+	}
+	for i, status := range statuses {
+		if status.Matched != 1 {
+			t.Errorf("applyWaivers() waiver %d matched %d results, want 1", i, status.Matched)
+		}
+	}
+}
+
+func TestScanOptionsInvalidWaiverResourceID(t *testing.T) {
+	model := IACPACResourceModel{
+		SeverityWeights: types.MapNull(types.Float64Type),
+		Waivers: []WaiverModel{
+			{RuleID: types.StringValue("EC2.1"), ResourceID: types.StringValue("aws_instance.web[0]")},
+			{RuleID: types.StringValue("EC2.1"), ResourceID: types.StringValue("aws_instance.web[")},
+		},
+	}
+	opts, diags := model.scanOptions(context.Background(), nil)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("scanOptions() diagnostics = %v, want one error", diags)
+	}
+	withPath, ok := diags[0].(interface{ Path() path.Path })
+	if want := path.Root("waiver").AtListIndex(1).AtName("resource_id"); !ok || !withPath.Path().Equal(want) {
+		t.Errorf("scanOptions() attached the error to %v, want %s", diags[0], want)
+	}
+	if len(opts.Waivers) != 1 || opts.Waivers[0].ResourceID != "aws_instance.web[0]" {
+		t.Errorf("scanOptions() waivers = %+v, want the valid waiver only", opts.Waivers)
+	}
+}
@@ -2,13 +2,21 @@ package starchitect
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"terraform-provider-starchitect/resources"
+	"terraform-provider-starchitect/resources/utils"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	prschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func New(version string) func() provider.Provider {
@@ -26,6 +34,124 @@ type starchitectProvider struct {
 	version string
 }
 
+// starchitectProviderModel describes the provider data model.
+type starchitectProviderModel struct {
+	Host            types.String  `tfsdk:"host"`
+	Username        types.String  `tfsdk:"username"`
+	Password        types.String  `tfsdk:"password"`
+	PACVersion      types.String  `tfsdk:"pac_version"`
+	PACRepository   types.String  `tfsdk:"pac_repository"`
+	PACSubdirectory types.String  `tfsdk:"pac_subdirectory"`
+	LogPath         types.String  `tfsdk:"log_path"`
+	EnginePath      types.String  `tfsdk:"engine_path"`
+	CacheDir        types.String  `tfsdk:"cache_dir"`
+	CacheTTL        types.String  `tfsdk:"cache_ttl"`
+	CacheRetention  types.String  `tfsdk:"cache_retention"`
+	Threshold       types.Float64 `tfsdk:"threshold"`
+	Offline         types.Bool    `tfsdk:"offline"`
+	RulePackPath    types.String  `tfsdk:"rule_pack_path"`
+	TaxonomyFile    types.String  `tfsdk:"taxonomy_file"`
+
+	PACAuth         *resources.PACAuthModel         `tfsdk:"pac_auth"`
+	PACVerification *resources.PACVerificationModel `tfsdk:"pac_verification"`
+}
+
+// stringWithEnv returns the configured value, or the environment variable when unset.
+func stringWithEnv(value types.String, env string) string {
+	if value.IsNull() || value.IsUnknown() {
+		return os.Getenv(env)
+	}
+	return value.ValueString()
+}
+
+// providerConfig builds the configuration inherited by resources and data sources.
+func (m starchitectProviderModel) providerConfig() (*resources.ProviderConfig, error) {
+	config := &resources.ProviderConfig{
+		PACVersion:      stringWithEnv(m.PACVersion, "STARCHITECT_PAC_VERSION"),
+		PACRepository:   stringWithEnv(m.PACRepository, "STARCHITECT_PAC_REPOSITORY"),
+		PACSubdirectory: stringWithEnv(m.PACSubdirectory, "STARCHITECT_PAC_SUBDIRECTORY"),
+		LogPath:         stringWithEnv(m.LogPath, "STARCHITECT_LOG_PATH"),
+		EnginePath:      stringWithEnv(m.EnginePath, "STARCHITECT_ENGINE_PATH"),
+		PACCache: utils.PACCacheOptions{
+			Dir:       stringWithEnv(m.CacheDir, "STARCHITECT_CACHE_DIR"),
+			TTL:       utils.DefaultPACCacheTTL,
+			Retention: utils.DefaultPACCacheRetention,
+		},
+	}
+
+	for _, duration := range []struct {
+		value  types.String
+		env    string
+		target *time.Duration
+	}{
+		{m.CacheTTL, "STARCHITECT_CACHE_TTL", &config.PACCache.TTL},
+		{m.CacheRetention, "STARCHITECT_CACHE_RETENTION", &config.PACCache.Retention},
+	} {
+		value := stringWithEnv(duration.value, duration.env)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative duration such as 30m or 24h, got %q", duration.env, value)
+		}
+		*duration.target = parsed
+	}
+
+	auth := resources.PACAuthModel{}
+	if m.PACAuth != nil {
+		auth = *m.PACAuth
+	}
+	config.PACAuth = utils.PACAuth{
+		Username:          stringWithEnv(auth.Username, "STARCHITECT_PAC_USERNAME"),
+		Token:             stringWithEnv(auth.Token, "STARCHITECT_PAC_TOKEN"),
+		SSHPrivateKey:     stringWithEnv(auth.SSHPrivateKey, "STARCHITECT_PAC_SSH_PRIVATE_KEY"),
+		SSHPrivateKeyFile: stringWithEnv(auth.SSHPrivateKeyFile, "STARCHITECT_PAC_SSH_PRIVATE_KEY_FILE"),
+		SSHKeyPassphrase:  stringWithEnv(auth.SSHKeyPassphrase, "STARCHITECT_PAC_SSH_KEY_PASSPHRASE"),
+	}
+
+	verification := resources.PACVerificationModel{}
+	if m.PACVerification != nil {
+		verification = *m.PACVerification
+	}
+	config.PACVerification = utils.PACVerification{
+		Commit:        stringWithEnv(verification.Commit, "STARCHITECT_PAC_COMMIT"),
+		ChecksumFile:  stringWithEnv(verification.ChecksumFile, "STARCHITECT_PAC_CHECKSUM_FILE"),
+		PublicKey:     stringWithEnv(verification.PublicKey, "STARCHITECT_PAC_PUBLIC_KEY"),
+		SignatureFile: stringWithEnv(verification.SignatureFile, "STARCHITECT_PAC_SIGNATURE_FILE"),
+	}
+	if config.PACVerification.PublicKey != "" && config.PACVerification.ChecksumFile == "" {
+		return nil, fmt.Errorf("pac_verification public_key requires a checksum_file to verify the signature of")
+	}
+
+	config.RulePackPath = stringWithEnv(m.RulePackPath, "STARCHITECT_RULE_PACK_PATH")
+	config.TaxonomyFile = stringWithEnv(m.TaxonomyFile, "STARCHITECT_TAXONOMY_FILE")
+	if !m.Offline.IsNull() && !m.Offline.IsUnknown() {
+		config.Offline = m.Offline.ValueBool()
+	} else if value := os.Getenv("STARCHITECT_OFFLINE"); value != "" {
+		offline, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("STARCHITECT_OFFLINE must be true or false, got %q", value)
+		}
+		config.Offline = offline
+	}
+	if config.Offline && config.RulePackPath == "" {
+		return nil, fmt.Errorf("offline requires rule_pack_path or STARCHITECT_RULE_PACK_PATH to resolve the policies from")
+	}
+
+	if !m.Threshold.IsNull() && !m.Threshold.IsUnknown() {
+		threshold := m.Threshold.ValueFloat64()
+		config.Threshold = &threshold
+	} else if value := os.Getenv("STARCHITECT_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil || threshold < 0 || threshold > 100 {
+			return nil, fmt.Errorf("STARCHITECT_THRESHOLD must be a number between 0 and 100, got %q", value)
+		}
+		config.Threshold = &threshold
+	}
+	return config, nil
+}
+
 // Metadata returns the provider type name.
 func (p *starchitectProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "starchitect"
@@ -35,28 +161,158 @@ func (p *starchitectProvider) Metadata(_ context.Context, _ provider.MetadataReq
 // Schema defines the provider-level schema for configuration data.
 func (p *starchitectProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = prschema.Schema{
+		Description: "Defaults inherited by every starchitect resource and data source. " +
+			"Every attribute falls back to its STARCHITECT_* environment variable",
 		Attributes: map[string]prschema.Attribute{
 			"host": prschema.StringAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "host is not used by the provider and will be removed",
 			},
 			"username": prschema.StringAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "username is not used by the provider and will be removed",
 			},
 			"password": prschema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: "password is not used by the provider and will be removed",
+			},
+			"pac_version": prschema.StringAttribute{
+				Description: "Default branch, tag or commit SHA of the PAC repository. Env: STARCHITECT_PAC_VERSION",
+				Optional:    true,
+			},
+			"pac_repository": prschema.StringAttribute{
+				Description: "Default PAC repository URL, https://github.com/nonfx/starchitect-cloudguard when unset. Env: STARCHITECT_PAC_REPOSITORY",
+				Optional:    true,
+			},
+			"pac_subdirectory": prschema.StringAttribute{
+				Description: "Default directory of the PAC repository holding the aws policies, terraform/aws when unset. Env: STARCHITECT_PAC_SUBDIRECTORY",
+				Optional:    true,
+			},
+			"log_path": prschema.StringAttribute{
+				Description: "Default path to store log files. Env: STARCHITECT_LOG_PATH",
+				Optional:    true,
+			},
+			"engine_path": prschema.StringAttribute{
+				Description: "Path to the executable of the regula engine, regula in PATH when unset. Env: STARCHITECT_ENGINE_PATH",
+				Optional:    true,
+			},
+			"cache_dir": prschema.StringAttribute{
+				Description: "Directory caching the cloned PAC repositories by repository and commit, starchitect/pac in the user cache directory when unset. Env: STARCHITECT_CACHE_DIR",
+				Optional:    true,
+			},
+			"cache_ttl": prschema.StringAttribute{
+				Description: "How long a resolved branch or tag is reused before the repository is asked for its current commit, e.g. 30m. " +
+					"0s refreshes on every scan, commit SHAs are never refreshed. Defaults to 1h. Env: STARCHITECT_CACHE_TTL",
+				Optional: true,
+			},
+			"cache_retention": prschema.StringAttribute{
+				Description: "How long an unused cached PAC clone is kept before it is removed, e.g. 72h. 0s keeps clones forever. Defaults to 168h. Env: STARCHITECT_CACHE_RETENTION",
+				Optional:    true,
+			},
+			"threshold": prschema.Float64Attribute{
+				Description: "Default minimum required security score (0-100). Env: STARCHITECT_THRESHOLD",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+				},
+			},
+			"offline": prschema.BoolAttribute{
+				Description: "Never touch the network. Policies are resolved from the rule packs in rule_pack_path " +
+					"instead of the PAC repository. Env: STARCHITECT_OFFLINE",
+				Optional: true,
+			},
+			"rule_pack_path": prschema.StringAttribute{
+				Description: "Rule pack used in offline mode: a directory or .tar, .tar.gz or .tgz archive holding a manifest.json " +
+					"that records the repository, commit and refs next to a checkout of the PAC repository, " +
+					"or a directory of such rule packs. Env: STARCHITECT_RULE_PACK_PATH",
+				Optional: true,
+			},
+			"taxonomy_file": prschema.StringAttribute{
+				Description: "Default YAML or JSON taxonomy merged over the taxonomy of the rule pack, " +
+					"mapping custom or internal resource types to taxons. Env: STARCHITECT_TAXONOMY_FILE",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]prschema.Block{
+			"pac_auth": prschema.SingleNestedBlock{
+				Description: "Default credentials of a private PAC repository",
+				Attributes: map[string]prschema.Attribute{
+					"username": prschema.StringAttribute{
+						Description: "Username, git when unset. Env: STARCHITECT_PAC_USERNAME",
+						Optional:    true,
+					},
+					"token": prschema.StringAttribute{
+						Description: "Access token of HTTP(S) repositories. Env: STARCHITECT_PAC_TOKEN",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"ssh_private_key": prschema.StringAttribute{
+						Description: "PEM encoded SSH private key of SSH repositories. Env: STARCHITECT_PAC_SSH_PRIVATE_KEY",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"ssh_private_key_file": prschema.StringAttribute{
+						Description: "Path to the SSH private key of SSH repositories. Env: STARCHITECT_PAC_SSH_PRIVATE_KEY_FILE",
+						Optional:    true,
+					},
+					"ssh_key_passphrase": prschema.StringAttribute{
+						Description: "Passphrase of the SSH private key. Env: STARCHITECT_PAC_SSH_KEY_PASSPHRASE",
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+			"pac_verification": prschema.SingleNestedBlock{
+				Description: "Default verification of the fetched policies, failing scans of tampered packs",
+				Attributes: map[string]prschema.Attribute{
+					"commit": prschema.StringAttribute{
+						Description: "Commit SHA the policies are pinned to. Env: STARCHITECT_PAC_COMMIT",
+						Optional:    true,
+					},
+					"checksum_file": prschema.StringAttribute{
+						Description: "sha256sum style manifest of every .rego file, relative to the root of the PAC repository or absolute. " +
+							"Env: STARCHITECT_PAC_CHECKSUM_FILE",
+						Optional: true,
+					},
+					"public_key": prschema.StringAttribute{
+						Description: "PEM encoded ed25519 public key the checksum_file must be signed with. Env: STARCHITECT_PAC_PUBLIC_KEY",
+						Optional:    true,
+					},
+					"signature_file": prschema.StringAttribute{
+						Description: "Base64 encoded ed25519 signature of the checksum_file, checksum_file with a .sig suffix when unset. " +
+							"Env: STARCHITECT_PAC_SIGNATURE_FILE",
+						Optional: true,
+					},
+				},
 			},
 		},
 	}
 }
 
-// Configure prepares a HashiCups API client for data sources and resources.
+// Configure passes the provider defaults to data sources and resources.
 func (p *starchitectProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var model starchitectProviderModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := model.providerConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Provider Configuration", err.Error())
+		return
+	}
+	resp.DataSourceData = config
+	resp.ResourceData = config
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *starchitectProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		resources.NewScanDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
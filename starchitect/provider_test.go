@@ -0,0 +1,84 @@
+package starchitect
+
+import (
+	"terraform-provider-starchitect/resources/utils"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderConfig(t *testing.T) {
+	t.Setenv("STARCHITECT_PAC_VERSION", "release")
+	t.Setenv("STARCHITECT_CACHE_DIR", "/tmp/starchitect")
+	t.Setenv("STARCHITECT_THRESHOLD", "75")
+
+	model := starchitectProviderModel{
+		PACVersion: types.StringValue("main"),
+		CacheDir:   types.StringNull(),
+		Threshold:  types.Float64Null(),
+	}
+	config, err := model.providerConfig()
+	if err != nil {
+		t.Fatalf("providerConfig() error = %v", err)
+	}
+	if config.PACVersion != "main" {
+		t.Errorf("PACVersion = %q, want the configured main", config.PACVersion)
+	}
+	if config.PACCache.Dir != "/tmp/starchitect" {
+		t.Errorf("PACCache.Dir = %q, want the environment /tmp/starchitect", config.PACCache.Dir)
+	}
+	if config.Threshold == nil || *config.Threshold != 75 {
+		t.Errorf("Threshold = %v, want 75", config.Threshold)
+	}
+
+	if config.PACCache.TTL != utils.DefaultPACCacheTTL || config.PACCache.Retention != utils.DefaultPACCacheRetention {
+		t.Errorf("PACCache = %+v, want the default TTL and retention", config.PACCache)
+	}
+
+	model.CacheTTL = types.StringValue("0s")
+	t.Setenv("STARCHITECT_CACHE_RETENTION", "24h")
+	config, err = model.providerConfig()
+	if err != nil || config.PACCache.TTL != 0 || config.PACCache.Retention != 24*time.Hour {
+		t.Errorf("providerConfig() cache = %+v, %v, want TTL 0s and retention 24h", config.PACCache, err)
+	}
+	model.CacheTTL = types.StringValue("soon")
+	if _, err := model.providerConfig(); err == nil {
+		t.Errorf("providerConfig() accepted an invalid cache_ttl")
+	}
+	model.CacheTTL = types.StringNull()
+
+	model.Threshold = types.Float64Value(40)
+	config, err = model.providerConfig()
+	if err != nil || config.Threshold == nil || *config.Threshold != 40 {
+		t.Errorf("providerConfig() threshold = %v, %v, want the configured 40", config.Threshold, err)
+	}
+
+	t.Setenv("STARCHITECT_THRESHOLD", "high")
+	model.Threshold = types.Float64Null()
+	if _, err := model.providerConfig(); err == nil {
+		t.Errorf("providerConfig() accepted an invalid STARCHITECT_THRESHOLD")
+	}
+
+	t.Setenv("STARCHITECT_THRESHOLD", "")
+
+	t.Setenv("STARCHITECT_OFFLINE", "true")
+	if _, err := model.providerConfig(); err == nil {
+		t.Errorf("providerConfig() accepted offline without a rule pack path")
+	}
+	t.Setenv("STARCHITECT_RULE_PACK_PATH", "/opt/starchitect/packs")
+	config, err = model.providerConfig()
+	if err != nil || !config.Offline || config.RulePackPath != "/opt/starchitect/packs" {
+		t.Errorf("providerConfig() = %+v, %v, want offline with the environment rule pack path", config, err)
+	}
+	model.Offline = types.BoolValue(false)
+	config, err = model.providerConfig()
+	if err != nil || config.Offline {
+		t.Errorf("providerConfig() offline = %v, %v, want the configured false", config.Offline, err)
+	}
+	model.Offline = types.BoolNull()
+	t.Setenv("STARCHITECT_OFFLINE", "sometimes")
+	if _, err := model.providerConfig(); err == nil {
+		t.Errorf("providerConfig() accepted an invalid STARCHITECT_OFFLINE")
+	}
+}